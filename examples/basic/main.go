@@ -31,25 +31,20 @@ func main() {
 	warpBuilder.SetDescription("This is my first warp created with the Go SDK")
 
 	// Add a contract action
-	contractAction := types.WarpContractAction{
-		Type:        types.ContractActionType,
-		Label:       "Deploy Contract",
-		Description: stringPtr("Deploys a new smart contract"),
-		Address:     "erd1qqqqqqqqqqqqqpgqt4g39q9vzm80aujs44wmvwn4kfn6czmtcrpslzjs4e",
-		Func:        stringPtr("deployContract"),
-		Args:        []string{"0x01", "0x02"},
-		GasLimit:    10000000,
-	}
-	warpBuilder.AddAction(contractAction)
+	warpBuilder.Contract("erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3").
+		Label("Deploy Contract").
+		Description("Deploys a new smart contract").
+		Func("deployContract").
+		Arg("hex:01").
+		Arg("hex:02").
+		GasLimit(10000000).
+		Add()
 
 	// Add a link action
-	linkAction := types.WarpLinkAction{
-		Type:        types.LinkActionType,
-		Label:       "Visit Website",
-		Description: stringPtr("Visit our website"),
-		URL:         "https://example.com",
-	}
-	warpBuilder.AddAction(linkAction)
+	warpBuilder.Link("https://example.com").
+		Label("Visit Website").
+		Description("Visit our website").
+		Add()
 
 	// Build the warp
 	warp, err := warpBuilder.Build()
@@ -90,7 +85,3 @@ func main() {
 	fmt.Println("QR code saved to warp-qr.png")
 }
 
-// Helper function to create a string pointer
-func stringPtr(s string) *string {
-	return &s
-} 
\ No newline at end of file
@@ -25,26 +25,22 @@ func main() {
 	sdk.Builder.SetDescription("This warp was created using the unified SDK")
 
 	// Add a contract action
-	contractAction := types.WarpContractAction{
-		Type:        types.ContractActionType,
-		Label:       "Execute Contract",
-		Description: stringPtr("Executes a smart contract function"),
-		Address:     "erd1qqqqqqqqqqqqqpgqt4g39q9vzm80aujs44wmvwn4kfn6czmtcrpslzjs4e",
-		Func:        stringPtr("execute"),
-		Args:        []string{"0x01", "0x02"},
-		GasLimit:    5000000,
-	}
-	sdk.Builder.AddAction(contractAction)
+	sdk.Builder.Contract("erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3").
+		Label("Execute Contract").
+		Description("Executes a smart contract function").
+		Func("execute").
+		Arg("hex:01").
+		Arg("hex:02").
+		GasLimit(5000000).
+		Add()
 
 	// Add a transfer action
-	transferAction := types.WarpTransferAction{
-		Type:        types.TransferActionType,
-		Label:       "Send EGLD",
-		Description: stringPtr("Sends EGLD to a recipient"),
-		Address:     stringPtr("erd1..."), // Replace with recipient address
-		Value:       stringPtr("0.1"),
-	}
-	sdk.Builder.AddAction(transferAction)
+	sdk.Builder.Transfer().
+		Label("Send EGLD").
+		Description("Sends EGLD to a recipient").
+		To("{{RECEIVER}}"). // Replace with recipient address
+		Value("0.1").
+		Add()
 
 	// Build the warp
 	warp, err := sdk.Builder.Build()
@@ -136,7 +132,3 @@ func main() {
 	fmt.Println("\nExample completed successfully!")
 }
 
-// Helper function to create a string pointer
-func stringPtr(s string) *string {
-	return &s
-} 
\ No newline at end of file
@@ -4,5 +4,6 @@ go 1.21
 
 require (
 	github.com/boombuler/barcode v1.0.1
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
-) 
\ No newline at end of file
+)
@@ -0,0 +1,300 @@
+// Package abi encodes contract call arguments and decodes contract results and events
+// using the types declared in a contract ABI
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// EnumValue represents a decoded enum variant, with its fields keyed by name if it has any.
+// Enum values can be encoded from an EnumValue, a variant name or a discriminant.
+type EnumValue struct {
+	Name         string                 `json:"name"`
+	Discriminant int                    `json:"discriminant"`
+	Fields       map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Codec encodes and decodes values according to a contract ABI.
+//
+// Values are represented as follows:
+//   - integers as *big.Int for BigUint and BigInt, and as the matching Go integer type for fixed sizes
+//   - Address as its bech32 string, bytes and ManagedBuffer as []byte, strings and token identifiers as string
+//   - Option and optional as nil or the value, List, variadic, tuple, multi and arrays as []interface{}
+//   - structs as map[string]interface{} keyed by field name, enums as EnumValue
+//
+// Encoding also accepts numbers and bools as strings, and any slice for lists.
+type Codec struct {
+	abi *types.AbiContents
+}
+
+// NewCodec creates a new Codec for the ABI
+func NewCodec(abi *types.AbiContents) *Codec {
+	return &Codec{abi: abi}
+}
+
+// EncodeArgs encodes the values of an endpoint's inputs into top level arguments.
+// Trailing optional inputs can be omitted, variadic inputs take a list of values.
+func (c *Codec) EncodeArgs(endpoint string, values ...interface{}) ([][]byte, error) {
+	e, found := c.abi.Endpoint(endpoint)
+	if !found {
+		return nil, fmt.Errorf("AbiCodec: unknown endpoint %s", endpoint)
+	}
+	if len(values) > len(e.Inputs) {
+		return nil, fmt.Errorf("AbiCodec: %s expects %d arguments, got %d", endpoint, len(e.Inputs), len(values))
+	}
+
+	var args [][]byte
+	for i, input := range e.Inputs {
+		inputType, err := parseType(input.Type)
+		if err != nil {
+			return nil, fmt.Errorf("AbiCodec: %s: %w", endpoint, err)
+		}
+
+		var value interface{}
+		if i < len(values) {
+			value = values[i]
+		} else if inputType.name != "optional" && inputType.name != "variadic" {
+			return nil, fmt.Errorf("AbiCodec: %s: missing argument %s", endpoint, input.Name)
+		}
+
+		inputArgs, err := c.encodeTop(inputType, value)
+		if err != nil {
+			return nil, fmt.Errorf("AbiCodec: %s: argument %s: %w", endpoint, input.Name, err)
+		}
+		args = append(args, inputArgs...)
+	}
+
+	return args, nil
+}
+
+// EncodeWarpArgs encodes warp action arguments into top level arguments of an endpoint.
+//
+// Arguments are written as "type:value" or as a plain value, and are converted to the type the ABI declares.
+// Values of structs, enums with fields, lists and tuples are written as JSON, "null" is None.
+// "hex:" arguments are passed through as raw encoded bytes. Extra arguments are collected into a
+// trailing variadic input.
+func (c *Codec) EncodeWarpArgs(endpoint string, warpArgs []string) ([][]byte, error) {
+	e, found := c.abi.Endpoint(endpoint)
+	if !found {
+		return nil, fmt.Errorf("AbiCodec: unknown endpoint %s", endpoint)
+	}
+
+	var args [][]byte
+	for i := 0; i < len(warpArgs); i++ {
+		if i >= len(e.Inputs) {
+			return nil, fmt.Errorf("AbiCodec: %s expects %d arguments, got %d", endpoint, len(e.Inputs), len(warpArgs))
+		}
+		input := e.Inputs[i]
+		inputType, err := parseType(input.Type)
+		if err != nil {
+			return nil, fmt.Errorf("AbiCodec: %s: %w", endpoint, err)
+		}
+
+		// A trailing variadic input takes all remaining arguments
+		items := warpArgs[i : i+1]
+		valueType := inputType
+		if inputType.name == "variadic" && i == len(e.Inputs)-1 {
+			items = warpArgs[i:]
+			valueType = inputType.args[0]
+			i = len(warpArgs)
+		}
+
+		for _, warpArg := range items {
+			raw, value, err := c.parseWarpArg(warpArg, valueType)
+			if err != nil {
+				return nil, fmt.Errorf("AbiCodec: %s: argument %s: %w", endpoint, input.Name, err)
+			}
+			if raw != nil {
+				args = append(args, raw)
+				continue
+			}
+
+			inputArgs, err := c.encodeTop(valueType, value)
+			if err != nil {
+				return nil, fmt.Errorf("AbiCodec: %s: argument %s: %w", endpoint, input.Name, err)
+			}
+			args = append(args, inputArgs...)
+		}
+	}
+
+	for _, input := range e.Inputs[min(len(warpArgs), len(e.Inputs)):] {
+		if !strings.HasPrefix(input.Type, "optional<") && !strings.HasPrefix(input.Type, "variadic<") {
+			return nil, fmt.Errorf("AbiCodec: %s: missing argument %s", endpoint, input.Name)
+		}
+	}
+
+	return args, nil
+}
+
+// EncodeContractAction encodes the call data of a contract action, "func@hex@hex"
+func (c *Codec) EncodeContractAction(action types.WarpContractAction) ([]byte, error) {
+	if action.Func == nil || *action.Func == "" {
+		return nil, errors.New("AbiCodec: contract action has no function")
+	}
+
+	args, err := c.EncodeWarpArgs(*action.Func, action.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	return CallData(*action.Func, args), nil
+}
+
+// DecodeOutputs decodes the return data of an endpoint, one value per declared output
+func (c *Codec) DecodeOutputs(endpoint string, returnData [][]byte) ([]interface{}, error) {
+	e, found := c.abi.Endpoint(endpoint)
+	if !found {
+		return nil, fmt.Errorf("AbiCodec: unknown endpoint %s", endpoint)
+	}
+
+	values := make([]interface{}, 0, len(e.Outputs))
+	consumed := 0
+	for _, output := range e.Outputs {
+		outputType, err := parseType(output.Type)
+		if err != nil {
+			return nil, fmt.Errorf("AbiCodec: %s: %w", endpoint, err)
+		}
+		value, n, err := c.decodeTop(outputType, returnData[consumed:])
+		if err != nil {
+			return nil, fmt.Errorf("AbiCodec: %s: output %s: %w", endpoint, output.Type, err)
+		}
+		values = append(values, value)
+		consumed += n
+	}
+	if consumed < len(returnData) {
+		return nil, fmt.Errorf("AbiCodec: %s: %d unexpected return values", endpoint, len(returnData)-consumed)
+	}
+
+	return values, nil
+}
+
+// DecodeEvent decodes the topics and data of an event into its fields keyed by name.
+// Topics are as logged on chain, starting with the event identifier. The data holds one
+// value per field that is not indexed.
+func (c *Codec) DecodeEvent(identifier string, topics [][]byte, data [][]byte) (map[string]interface{}, error) {
+	event, found := c.abi.Event(identifier)
+	if !found {
+		return nil, fmt.Errorf("AbiCodec: unknown event %s", identifier)
+	}
+	if len(topics) > 0 && string(topics[0]) == identifier {
+		topics = topics[1:]
+	}
+
+	fields := make(map[string]interface{}, len(event.Inputs))
+	for _, input := range event.Inputs {
+		source := &data
+		if input.Indexed {
+			source = &topics
+		}
+
+		inputType, err := parseType(input.Type)
+		if err != nil {
+			return nil, fmt.Errorf("AbiCodec: %s: %w", identifier, err)
+		}
+		value, n, err := c.decodeTop(inputType, *source)
+		if err != nil {
+			return nil, fmt.Errorf("AbiCodec: %s: field %s: %w", identifier, input.Name, err)
+		}
+		fields[input.Name] = value
+		*source = (*source)[n:]
+	}
+
+	return fields, nil
+}
+
+// CallData joins a function name and top level arguments into transaction data, "func@hex@hex"
+func CallData(funcName string, args [][]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(funcName)
+	for _, arg := range args {
+		buf.WriteByte('@')
+		buf.WriteString(hex.EncodeToString(arg))
+	}
+	return buf.Bytes()
+}
+
+// warpArgTypes lists the type prefixes of warp arguments
+var warpArgTypes = map[string]bool{
+	string(types.StringInputType):   true,
+	string(types.Uint8InputType):    true,
+	string(types.Uint16InputType):   true,
+	string(types.Uint32InputType):   true,
+	string(types.Uint64InputType):   true,
+	string(types.BigUintInputType):  true,
+	string(types.BoolInputType):     true,
+	string(types.AddressInputType):  true,
+	string(types.TokenInputType):    true,
+	string(types.CodeMetaInputType): true,
+	string(types.EsdtInputType):     true,
+	string(types.NftInputType):      true,
+	"option":                        true,
+	"optional":                      true,
+	"list":                          true,
+	"variadic":                      true,
+	"composite":                     true,
+}
+
+// parseWarpArg converts a warp argument to a value of the type, or to raw bytes for "hex:" arguments
+func (c *Codec) parseWarpArg(warpArg string, t *typeExpr) ([]byte, interface{}, error) {
+	value := warpArg
+	separator := constants.WarpConstants.ArgParamsSeparator
+	if prefix, rest, found := strings.Cut(warpArg, separator); found {
+		if prefix == string(types.HexInputType) {
+			raw, err := hex.DecodeString(rest)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid hex %q", rest)
+			}
+			return raw, nil, nil
+		}
+		if warpArgTypes[prefix] {
+			value = rest
+		}
+	}
+
+	if !c.isComposite(t) {
+		if value == "null" && (t.name == "Option" || t.name == "optional") {
+			return nil, nil, nil
+		}
+		if t.name == "Option" || t.name == "optional" {
+			return c.parseWarpArg(value, t.args[0])
+		}
+		return nil, value, nil
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s value %q: %w", t, value, err)
+	}
+	return nil, decoded, nil
+}
+
+// isComposite returns whether values of the type are written as JSON in warp arguments
+func (c *Codec) isComposite(t *typeExpr) bool {
+	switch t.name {
+	case "List", "vec", "array", "tuple", "multi", "variadic":
+		return true
+	case "Option", "optional":
+		return c.isComposite(t.args[0])
+	}
+	_, fixed := fixedIntWidths[t.name]
+	if fixed || isBytesType(t.name) {
+		return false
+	}
+	switch t.name {
+	case "BigUint", "BigInt", "bool", "Address", "H256", "CodeMetadata":
+		return false
+	}
+	// Simple enums are written by variant name, other custom types as JSON
+	typeDef, found := c.abi.TypeDef(t.name)
+	return !found || !isSimpleEnum(typeDef)
+}
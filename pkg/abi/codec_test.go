@@ -0,0 +1,341 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+const (
+	alice    = "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th"
+	aliceHex = "0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1"
+)
+
+// testAbi declares an endpoint per encoding feature covered by the tests
+const testAbi = `{
+	"name": "Codec",
+	"endpoints": [
+		{"name": "scalars", "mutability": "mutable", "inputs": [
+			{"name": "count", "type": "u64"},
+			{"name": "amount", "type": "BigUint"},
+			{"name": "delta", "type": "BigInt"},
+			{"name": "to", "type": "Address"},
+			{"name": "label", "type": "bytes"},
+			{"name": "flag", "type": "bool"},
+			{"name": "small", "type": "i8"}
+		], "outputs": []},
+		{"name": "composites", "mutability": "mutable", "inputs": [
+			{"name": "maybe", "type": "Option<u64>"},
+			{"name": "ids", "type": "List<u32>"},
+			{"name": "position", "type": "Position"},
+			{"name": "status", "type": "Status"},
+			{"name": "action", "type": "Action"},
+			{"name": "memo", "type": "optional<bytes>"}
+		], "outputs": []},
+		{"name": "batch", "mutability": "mutable", "inputs": [
+			{"name": "entries", "type": "variadic<multi<Address,BigUint>>", "multi_arg": true}
+		], "outputs": []},
+		{"name": "getPosition", "mutability": "readonly", "inputs": [], "outputs": [
+			{"type": "Position"},
+			{"type": "Status"},
+			{"type": "variadic<u8>", "multi_result": true}
+		]}
+	],
+	"events": [
+		{"identifier": "deposit", "inputs": [
+			{"name": "caller", "type": "Address", "indexed": true},
+			{"name": "status", "type": "Status", "indexed": true},
+			{"name": "position", "type": "Position"}
+		]}
+	],
+	"types": {
+		"Position": {"type": "struct", "fields": [
+			{"name": "owner", "type": "Address"},
+			{"name": "amount", "type": "BigUint"},
+			{"name": "tags", "type": "List<utf-8 string>"},
+			{"name": "expiry", "type": "Option<u64>"}
+		]},
+		"Status": {"type": "enum", "variants": [
+			{"name": "Inactive", "discriminant": 0},
+			{"name": "Running", "discriminant": 1}
+		]},
+		"Action": {"type": "enum", "variants": [
+			{"name": "Stop", "discriminant": 0},
+			{"name": "Send", "discriminant": 1, "fields": [{"name": "amount", "type": "u32"}]}
+		]}
+	}
+}`
+
+func newTestCodec(t *testing.T) *Codec {
+	t.Helper()
+
+	var contents types.AbiContents
+	if err := json.Unmarshal([]byte(testAbi), &contents); err != nil {
+		t.Fatalf("invalid test ABI: %v", err)
+	}
+	return NewCodec(&contents)
+}
+
+// hexArgs returns the hex encoding of top level arguments
+func hexArgs(args [][]byte) []string {
+	encoded := make([]string, len(args))
+	for i, arg := range args {
+		encoded[i] = hex.EncodeToString(arg)
+	}
+	return encoded
+}
+
+func TestEncodeArgsScalars(t *testing.T) {
+	args, err := newTestCodec(t).EncodeArgs("scalars", uint64(5), big.NewInt(1000), big.NewInt(-129), alice, []byte("abc"), false, -1)
+	if err != nil {
+		t.Fatalf("EncodeArgs() error = %v", err)
+	}
+
+	expected := []string{"05", "03e8", "ff7f", aliceHex, "616263", "", "ff"}
+	if got := hexArgs(args); !reflect.DeepEqual(got, expected) {
+		t.Errorf("EncodeArgs() = %v, expected %v", got, expected)
+	}
+
+	if _, err := newTestCodec(t).EncodeArgs("scalars", 5, 1, 1, alice, "a", true, 128); err == nil {
+		t.Error("EncodeArgs() error = nil, expected an i8 overflow")
+	}
+}
+
+func TestEncodeArgsComposites(t *testing.T) {
+	position := map[string]interface{}{
+		"owner":  alice,
+		"amount": "1",
+		"tags":   []string{"a"},
+		"expiry": nil,
+	}
+	args, err := newTestCodec(t).EncodeArgs("composites",
+		5,
+		[]uint32{1, 2},
+		position,
+		"Running",
+		EnumValue{Name: "Send", Fields: map[string]interface{}{"amount": 7}},
+	)
+	if err != nil {
+		t.Fatalf("EncodeArgs() error = %v", err)
+	}
+
+	expected := []string{
+		"010000000000000005",
+		"0000000100000002",
+		aliceHex + "0000000101" + "00000001" + "0000000161" + "00",
+		"01",
+		"01" + "00000007",
+	}
+	if got := hexArgs(args); !reflect.DeepEqual(got, expected) {
+		t.Errorf("EncodeArgs() = %v, expected %v", got, expected)
+	}
+}
+
+func TestEncodeContractAction(t *testing.T) {
+	fn := "batch"
+	action := types.WarpContractAction{
+		Type:  types.ContractActionType,
+		Label: "Batch",
+		Func:  &fn,
+		Args:  []string{`["` + alice + `", "10"]`, `["` + alice + `", 11]`},
+	}
+
+	data, err := newTestCodec(t).EncodeContractAction(action)
+	if err != nil {
+		t.Fatalf("EncodeContractAction() error = %v", err)
+	}
+	expected := "batch@" + aliceHex + "@0a@" + aliceHex + "@0b"
+	if string(data) != expected {
+		t.Errorf("EncodeContractAction() = %s, expected %s", data, expected)
+	}
+
+	fn = "composites"
+	action.Args = []string{
+		"option:uint64:5",
+		"[1,2]",
+		`{"owner": "` + alice + `", "amount": "1", "tags": [], "expiry": 9}`,
+		"Inactive",
+		`{"name": "Stop"}`,
+		"hex:ff",
+	}
+	data, err = newTestCodec(t).EncodeContractAction(action)
+	if err != nil {
+		t.Fatalf("EncodeContractAction() error = %v", err)
+	}
+	parts := strings.Split(string(data), "@")
+	expectedParts := []string{"composites", "010000000000000005", "0000000100000002", aliceHex + "0000000101" + "00000000" + "01" + "0000000000000009", "", "00", "ff"}
+	if !reflect.DeepEqual(parts, expectedParts) {
+		t.Errorf("EncodeContractAction() = %v, expected %v", parts, expectedParts)
+	}
+}
+
+func TestDecodeOutputs(t *testing.T) {
+	codec := newTestCodec(t)
+	position, _ := hex.DecodeString(aliceHex + "00000002" + "03e8" + "00000001" + "0000000161" + "01" + "0000000000000009")
+
+	values, err := codec.DecodeOutputs("getPosition", [][]byte{position, {1}, {4}, {5}})
+	if err != nil {
+		t.Fatalf("DecodeOutputs() error = %v", err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"owner":  alice,
+			"amount": big.NewInt(1000),
+			"tags":   []interface{}{"a"},
+			"expiry": uint64(9),
+		},
+		EnumValue{Name: "Running", Discriminant: 1},
+		[]interface{}{uint8(4), uint8(5)},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("DecodeOutputs() = %#v, expected %#v", values, expected)
+	}
+
+	if _, err := codec.DecodeOutputs("getPosition", [][]byte{position[:10]}); err == nil {
+		t.Error("DecodeOutputs() error = nil, expected truncated data")
+	}
+}
+
+func TestDecodeOutputsHugeListLength(t *testing.T) {
+	codec := newTestCodec(t)
+	position, _ := hex.DecodeString(aliceHex + "00000000" + "ffffffff")
+
+	if _, err := codec.DecodeOutputs("getPosition", [][]byte{position}); err == nil {
+		t.Error("DecodeOutputs() error = nil, expected a list length beyond the data")
+	}
+}
+
+func TestCodecUntrustedSizes(t *testing.T) {
+	var contents types.AbiContents
+	err := json.Unmarshal([]byte(`{
+		"name": "Untrusted",
+		"endpoints": [
+			{"name": "huge", "mutability": "readonly", "inputs": [
+				{"name": "items", "type": "array2000000000<u8>"}
+			], "outputs": [
+				{"type": "array2000000000<u8>"}
+			]},
+			{"name": "empties", "mutability": "readonly", "inputs": [], "outputs": [
+				{"type": "List<Empty>"}
+			]}
+		],
+		"types": {
+			"Empty": {"type": "struct", "fields": []}
+		}
+	}`), &contents)
+	if err != nil {
+		t.Fatalf("invalid test ABI: %v", err)
+	}
+	codec := NewCodec(&contents)
+
+	if _, err := codec.DecodeOutputs("huge", [][]byte{{1, 2, 3}}); err == nil {
+		t.Error("DecodeOutputs() error = nil, expected an array size beyond the data")
+	}
+	if _, err := codec.EncodeArgs("huge", []interface{}{uint8(1)}); err == nil {
+		t.Error("EncodeArgs() error = nil, expected a wrong number of array values")
+	}
+	if _, err := codec.DecodeOutputs("empties", [][]byte{{1}}); err == nil {
+		t.Error("DecodeOutputs() error = nil, expected items of 0 bytes not to fill the data")
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	codec := newTestCodec(t)
+	caller, _ := hex.DecodeString(aliceHex)
+	position, _ := hex.DecodeString(aliceHex + "00000000" + "00000000" + "00")
+
+	fields, err := codec.DecodeEvent("deposit", [][]byte{[]byte("deposit"), caller, {}}, [][]byte{position})
+	if err != nil {
+		t.Fatalf("DecodeEvent() error = %v", err)
+	}
+
+	if fields["caller"] != alice {
+		t.Errorf("caller = %v, expected %s", fields["caller"], alice)
+	}
+	if status, ok := fields["status"].(EnumValue); !ok || status.Name != "Inactive" {
+		t.Errorf("status = %v, expected Inactive", fields["status"])
+	}
+	if p, ok := fields["position"].(map[string]interface{}); !ok || p["amount"].(*big.Int).Sign() != 0 || p["expiry"] != nil {
+		t.Errorf("position = %v, expected an empty position", fields["position"])
+	}
+}
+
+func TestParseType(t *testing.T) {
+	for _, expr := range []string{"u64", "List<Option<Position>>", "variadic<multi<Address,BigUint>>", "array32<u8>", "tuple<u8,List<bytes>>"} {
+		parsed, err := parseType(expr)
+		if err != nil {
+			t.Errorf("parseType(%s) error = %v", expr, err)
+			continue
+		}
+		if parsed.String() != expr {
+			t.Errorf("parseType(%s).String() = %s", expr, parsed)
+		}
+	}
+
+	for _, expr := range []string{"", "List<u8", "Option<u8,u16>", "List", "array-5<u8>"} {
+		if _, err := parseType(expr); err == nil {
+			t.Errorf("parseType(%q) error = nil, expected an invalid type", expr)
+		}
+	}
+}
+
+func TestAcceptsWarpType(t *testing.T) {
+	codec := newTestCodec(t)
+
+	cases := []struct {
+		abiType  string
+		warpType string
+		accepts  bool
+	}{
+		{"u64", "uint32", true},
+		{"u8", "uint16", false},
+		{"i64", "uint8", false},
+		{"BigUint", "uint64", true},
+		{"u64", "biguint", false},
+		{"Address", "address", true},
+		{"Address", "string", false},
+		{"Option<u64>", "uint64", true},
+		{"Option<u64>", "option:uint64", true},
+		{"List<u32>", "list:address", false},
+		{"Status", "string", true},
+		{"Position", "composite", true},
+		{"bool", "hex", true},
+		{"variadic<u64>", "uint64", true},
+		{"variadic<u64>", "address", false},
+		{"variadic<u64>", "variadic:uint32", true},
+	}
+	for _, c := range cases {
+		accepts, err := codec.AcceptsWarpType(c.abiType, c.warpType)
+		if err != nil || accepts != c.accepts {
+			t.Errorf("AcceptsWarpType(%s, %s) = %v, %v, expected %v", c.abiType, c.warpType, accepts, err, c.accepts)
+		}
+	}
+
+	for arg, expected := range map[string]string{"uint64:5": "uint64", "option:uint64:5": "option:uint64", "hex:ff": "hex", "5": "", "Running": ""} {
+		if got := WarpArgType(arg); got != expected {
+			t.Errorf("WarpArgType(%s) = %q, expected %q", arg, got, expected)
+		}
+	}
+}
+
+func TestTopLevelTypes(t *testing.T) {
+	cases := map[string][]string{
+		"u64":                              {"u64"},
+		"multi<Address,BigUint>":           {"Address", "BigUint"},
+		"multi<u8,multi<bytes,bool>>":      {"u8", "bytes", "bool"},
+		"List<multi<u8,u16>>":              {"List<multi<u8,u16>>"},
+		"variadic<multi<Address,BigUint>>": {"variadic<multi<Address,BigUint>>"},
+	}
+	for abiType, expected := range cases {
+		itemTypes, err := TopLevelTypes(abiType)
+		if err != nil || !reflect.DeepEqual(itemTypes, expected) {
+			t.Errorf("TopLevelTypes(%s) = %v, %v, expected %v", abiType, itemTypes, err, expected)
+		}
+	}
+}
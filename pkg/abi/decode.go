@@ -0,0 +1,394 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// decodeTop decodes top level arguments into a value, and returns the number of arguments it consumed.
+// Multi-value types consume any number of arguments, all other types exactly one.
+func (c *Codec) decodeTop(t *typeExpr, args [][]byte) (interface{}, int, error) {
+	switch t.name {
+	case "optional":
+		if len(args) == 0 {
+			return nil, 0, nil
+		}
+		return c.decodeTop(t.args[0], args)
+
+	case "variadic":
+		items := []interface{}{}
+		consumed := 0
+		for consumed < len(args) {
+			item, n, err := c.decodeTop(t.args[0], args[consumed:])
+			if err != nil {
+				return nil, 0, err
+			}
+			if n == 0 {
+				break
+			}
+			items = append(items, item)
+			consumed += n
+		}
+		return items, consumed, nil
+
+	case "multi":
+		items := make([]interface{}, len(t.args))
+		consumed := 0
+		for i, itemType := range t.args {
+			item, n, err := c.decodeTop(itemType, args[consumed:])
+			if err != nil {
+				return nil, 0, err
+			}
+			items[i] = item
+			consumed += n
+		}
+		return items, consumed, nil
+	}
+
+	if len(args) == 0 {
+		return nil, 0, fmt.Errorf("missing value for %s", t)
+	}
+	value, err := c.decodeTopValue(t, args[0])
+	if err != nil {
+		return nil, 0, err
+	}
+	return value, 1, nil
+}
+
+// decodeTopValue decodes a single top level argument
+func (c *Codec) decodeTopValue(t *typeExpr, data []byte) (interface{}, error) {
+	if width, fixed := fixedIntWidths[t.name]; fixed {
+		if len(data) > width {
+			return nil, fmt.Errorf("value of %d bytes overflows %s", len(data), t.name)
+		}
+		return fixedInt(t.name, leftPadSigned(data, width, isSigned(t.name))), nil
+	}
+
+	switch {
+	case t.name == "BigUint":
+		return new(big.Int).SetBytes(data), nil
+	case t.name == "BigInt":
+		return signedInt(data), nil
+	case isBytesType(t.name):
+		if isStringType(t.name) {
+			return string(data), nil
+		}
+		return data, nil
+	}
+
+	switch t.name {
+	case "bool":
+		if len(data) > 1 || (len(data) == 1 && data[0] > 1) {
+			return nil, fmt.Errorf("invalid bool %x", data)
+		}
+		return len(data) == 1 && data[0] == 1, nil
+
+	case "Option":
+		if len(data) == 0 {
+			return nil, nil
+		}
+		if data[0] != 1 {
+			return nil, fmt.Errorf("invalid option %x", data)
+		}
+		return c.decodeAll(t.args[0], data[1:])
+
+	case "List", "vec":
+		items := []interface{}{}
+		r := &reader{data: data}
+		for r.remaining() > 0 {
+			remaining := r.remaining()
+			item, err := c.decodeNested(t.args[0], r)
+			if err != nil {
+				return nil, err
+			}
+			if r.remaining() == remaining {
+				return nil, fmt.Errorf("%s items of 0 bytes cannot fill the %d remaining bytes", t.args[0], remaining)
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	// Simple enums are encoded as their discriminant at the top level
+	if typeDef, found := c.abi.TypeDef(t.name); found && isSimpleEnum(typeDef) {
+		if len(data) > 1 {
+			return nil, fmt.Errorf("invalid %s discriminant %x", t.name, data)
+		}
+		discriminant := 0
+		if len(data) == 1 {
+			discriminant = int(data[0])
+		}
+		return variantByDiscriminant(t.name, typeDef, discriminant)
+	}
+
+	return c.decodeAll(t, data)
+}
+
+// decodeAll decodes a nested value that must span all of the data
+func (c *Codec) decodeAll(t *typeExpr, data []byte) (interface{}, error) {
+	r := &reader{data: data}
+	value, err := c.decodeNested(t, r)
+	if err != nil {
+		return nil, err
+	}
+	if r.remaining() > 0 {
+		return nil, fmt.Errorf("%d unexpected bytes after %s", r.remaining(), t)
+	}
+	return value, nil
+}
+
+// decodeNested decodes a value nested in another value
+func (c *Codec) decodeNested(t *typeExpr, r *reader) (interface{}, error) {
+	if width, fixed := fixedIntWidths[t.name]; fixed {
+		data, err := r.read(width)
+		if err != nil {
+			return nil, err
+		}
+		return fixedInt(t.name, data), nil
+	}
+
+	switch {
+	case t.name == "BigUint" || t.name == "BigInt" || isBytesType(t.name):
+		data, err := r.readBuffer()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case t.name == "BigUint":
+			return new(big.Int).SetBytes(data), nil
+		case t.name == "BigInt":
+			return signedInt(data), nil
+		case isStringType(t.name):
+			return string(data), nil
+		}
+		return data, nil
+	}
+
+	switch t.name {
+	case "bool":
+		data, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		if data[0] > 1 {
+			return nil, fmt.Errorf("invalid bool %x", data)
+		}
+		return data[0] == 1, nil
+
+	case "Address":
+		data, err := r.read(address.PubKeyLength)
+		if err != nil {
+			return nil, err
+		}
+		return address.Encode(data)
+
+	case "H256":
+		return r.read(32)
+
+	case "CodeMetadata":
+		return r.read(2)
+
+	case "Option":
+		flag, err := r.read(1)
+		if err != nil {
+			return nil, err
+		}
+		switch flag[0] {
+		case 0:
+			return nil, nil
+		case 1:
+			return c.decodeNested(t.args[0], r)
+		}
+		return nil, fmt.Errorf("invalid option flag %x", flag)
+
+	case "List", "vec":
+		length, err := r.readLength()
+		if err != nil {
+			return nil, err
+		}
+		// every item takes at least one byte, so a longer list cannot be complete
+		if length > r.remaining() {
+			return nil, fmt.Errorf("list of %d items exceeds the %d remaining bytes", length, r.remaining())
+		}
+		return c.decodeItems(repeat(t.args[0], length), r)
+
+	case "array":
+		if t.size > r.remaining() {
+			return nil, fmt.Errorf("array of %d items exceeds the %d remaining bytes", t.size, r.remaining())
+		}
+		return c.decodeItems(repeat(t.args[0], t.size), r)
+
+	case "tuple":
+		return c.decodeItems(t.args, r)
+
+	case "optional", "variadic", "multi":
+		return nil, fmt.Errorf("%s can only be used as a top level argument", t)
+	}
+
+	typeDef, found := c.abi.TypeDef(t.name)
+	if !found {
+		return nil, fmt.Errorf("unknown type %s", t.name)
+	}
+
+	if typeDef.Type == types.AbiStruct {
+		return c.decodeFields(t.name, typeDef.Fields, r)
+	}
+
+	discriminant, err := r.read(1)
+	if err != nil {
+		return nil, err
+	}
+	enum, err := variantByDiscriminant(t.name, typeDef, int(discriminant[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	variant, _ := findVariant(t.name, typeDef, enum)
+	if len(variant.Fields) > 0 {
+		fields, err := c.decodeFields(t.name+"::"+variant.Name, variant.Fields, r)
+		if err != nil {
+			return nil, err
+		}
+		enum.Fields = fields
+	}
+	return enum, nil
+}
+
+// decodeItems decodes consecutive nested values
+func (c *Codec) decodeItems(itemTypes []*typeExpr, r *reader) ([]interface{}, error) {
+	items := make([]interface{}, len(itemTypes))
+	for i, itemType := range itemTypes {
+		item, err := c.decodeNested(itemType, r)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
+// decodeFields decodes the fields of a struct or enum variant, keyed by field name
+func (c *Codec) decodeFields(owner string, declared []types.AbiField, r *reader) (map[string]interface{}, error) {
+	fields := make(map[string]interface{}, len(declared))
+	for _, field := range declared {
+		fieldType, err := parseType(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.decodeNested(fieldType, r)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", owner, field.Name, err)
+		}
+		fields[field.Name] = value
+	}
+	return fields, nil
+}
+
+// variantByDiscriminant returns the enum value of the variant with the discriminant
+func variantByDiscriminant(name string, typeDef *types.AbiTypeDef, discriminant int) (EnumValue, error) {
+	for _, variant := range typeDef.Variants {
+		if variant.Discriminant == discriminant {
+			return EnumValue{Name: variant.Name, Discriminant: discriminant}, nil
+		}
+	}
+	return EnumValue{}, fmt.Errorf("unknown %s discriminant %d", name, discriminant)
+}
+
+// repeat returns a list of n times the same type
+func repeat(t *typeExpr, n int) []*typeExpr {
+	itemTypes := make([]*typeExpr, n)
+	for i := range itemTypes {
+		itemTypes[i] = t
+	}
+	return itemTypes
+}
+
+// fixedInt converts the big-endian bytes of a fixed size integer to its Go type
+func fixedInt(name string, data []byte) interface{} {
+	var u uint64
+	for _, b := range data {
+		u = u<<8 | uint64(b)
+	}
+
+	switch name {
+	case "u8":
+		return uint8(u)
+	case "u16":
+		return uint16(u)
+	case "u32", "usize":
+		return uint32(u)
+	case "u64":
+		return u
+	case "i8":
+		return int8(u)
+	case "i16":
+		return int16(u)
+	case "i32", "isize":
+		return int32(u)
+	}
+	return int64(u)
+}
+
+// leftPadSigned pads the minimal encoding of an integer to the width, extending the sign of signed integers
+func leftPadSigned(data []byte, width int, signed bool) []byte {
+	padded := make([]byte, width)
+	if signed && len(data) > 0 && data[0]&0x80 != 0 {
+		for i := range padded {
+			padded[i] = 0xff
+		}
+	}
+	copy(padded[width-len(data):], data)
+	return padded
+}
+
+// signedInt decodes a two's complement big-endian integer
+func signedInt(data []byte) *big.Int {
+	n := new(big.Int).SetBytes(data)
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(data)*8)))
+	}
+	return n
+}
+
+// reader reads nested values from encoded data
+type reader struct {
+	data   []byte
+	offset int
+}
+
+// remaining returns the number of bytes left to read
+func (r *reader) remaining() int {
+	return len(r.data) - r.offset
+}
+
+// read reads n bytes
+func (r *reader) read(n int) ([]byte, error) {
+	if n < 0 || r.remaining() < n {
+		return nil, fmt.Errorf("unexpected end of data, expected %d more bytes", n)
+	}
+	data := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return data, nil
+}
+
+// readLength reads a 4 bytes length prefix
+func (r *reader) readLength() (int, error) {
+	data, err := r.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint32(data)), nil
+}
+
+// readBuffer reads a length prefixed buffer
+func (r *reader) readBuffer() ([]byte, error) {
+	length, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	return r.read(length)
+}
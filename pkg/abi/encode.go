@@ -0,0 +1,544 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// encodeTop encodes a value as top level arguments. Multi-value types span any number of arguments.
+func (c *Codec) encodeTop(t *typeExpr, value interface{}) ([][]byte, error) {
+	switch t.name {
+	case "optional":
+		if value == nil {
+			return nil, nil
+		}
+		return c.encodeTop(t.args[0], value)
+
+	case "variadic":
+		items, err := toList(value)
+		if err != nil {
+			return nil, err
+		}
+		var args [][]byte
+		for _, item := range items {
+			itemArgs, err := c.encodeTop(t.args[0], item)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, itemArgs...)
+		}
+		return args, nil
+
+	case "multi":
+		items, err := toList(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(items) != len(t.args) {
+			return nil, fmt.Errorf("%s expects %d values, got %d", t, len(t.args), len(items))
+		}
+		var args [][]byte
+		for i, item := range items {
+			itemArgs, err := c.encodeTop(t.args[i], item)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, itemArgs...)
+		}
+		return args, nil
+	}
+
+	arg, err := c.encodeTopValue(t, value)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{arg}, nil
+}
+
+// encodeTopValue encodes a value as a single top level argument
+func (c *Codec) encodeTopValue(t *typeExpr, value interface{}) ([]byte, error) {
+	if _, fixed := fixedIntWidths[t.name]; fixed || t.name == "BigUint" || t.name == "BigInt" {
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkIntRange(t.name, n); err != nil {
+			return nil, err
+		}
+		if isSigned(t.name) {
+			return signedBytes(n, 0), nil
+		}
+		return n.Bytes(), nil
+	}
+
+	if isBytesType(t.name) {
+		return toBytes(value)
+	}
+
+	switch t.name {
+	case "bool":
+		b, err := toBool(value)
+		if err != nil || !b {
+			return nil, err
+		}
+		return []byte{1}, nil
+
+	case "Option":
+		if value == nil {
+			return nil, nil
+		}
+		var buf bytes.Buffer
+		buf.WriteByte(1)
+		if err := c.encodeNested(t.args[0], value, &buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+
+	case "List", "vec":
+		items, err := toList(value)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		for _, item := range items {
+			if err := c.encodeNested(t.args[0], item, &buf); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+	}
+
+	// Simple enums are encoded as their discriminant at the top level
+	if typeDef, found := c.abi.TypeDef(t.name); found && isSimpleEnum(typeDef) {
+		variant, err := findVariant(t.name, typeDef, value)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(variant.Discriminant)).Bytes(), nil
+	}
+
+	var buf bytes.Buffer
+	if err := c.encodeNested(t, value, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeNested encodes a value as nested in another value
+func (c *Codec) encodeNested(t *typeExpr, value interface{}, buf *bytes.Buffer) error {
+	if width, fixed := fixedIntWidths[t.name]; fixed {
+		n, err := toBigInt(value)
+		if err != nil {
+			return err
+		}
+		if err := checkIntRange(t.name, n); err != nil {
+			return err
+		}
+		if isSigned(t.name) {
+			buf.Write(signedBytes(n, width))
+		} else {
+			buf.Write(leftPad(n.Bytes(), width))
+		}
+		return nil
+	}
+
+	switch {
+	case t.name == "BigUint" || t.name == "BigInt":
+		n, err := toBigInt(value)
+		if err != nil {
+			return err
+		}
+		if err := checkIntRange(t.name, n); err != nil {
+			return err
+		}
+		data := n.Bytes()
+		if t.name == "BigInt" {
+			data = signedBytes(n, 0)
+		}
+		writeLength(buf, len(data))
+		buf.Write(data)
+		return nil
+
+	case isBytesType(t.name):
+		data, err := toBytes(value)
+		if err != nil {
+			return err
+		}
+		writeLength(buf, len(data))
+		buf.Write(data)
+		return nil
+	}
+
+	switch t.name {
+	case "bool":
+		b, err := toBool(value)
+		if err != nil {
+			return err
+		}
+		if b {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		return nil
+
+	case "Address":
+		pubKey, err := toAddress(value)
+		if err != nil {
+			return err
+		}
+		buf.Write(pubKey)
+		return nil
+
+	case "H256", "CodeMetadata":
+		size := 32
+		if t.name == "CodeMetadata" {
+			size = 2
+		}
+		data, err := toFixedBytes(value, size)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		return nil
+
+	case "Option":
+		if value == nil {
+			buf.WriteByte(0)
+			return nil
+		}
+		buf.WriteByte(1)
+		return c.encodeNested(t.args[0], value, buf)
+
+	case "List", "vec":
+		items, err := toList(value)
+		if err != nil {
+			return err
+		}
+		writeLength(buf, len(items))
+		for _, item := range items {
+			if err := c.encodeNested(t.args[0], item, buf); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "array", "tuple":
+		items, err := toList(value)
+		if err != nil {
+			return err
+		}
+		itemTypes := t.args
+		if t.name == "array" {
+			if len(items) != t.size {
+				return fmt.Errorf("%s expects %d values, got %d", t, t.size, len(items))
+			}
+			itemTypes = repeat(t.args[0], t.size)
+		}
+		if len(items) != len(itemTypes) {
+			return fmt.Errorf("%s expects %d values, got %d", t, len(itemTypes), len(items))
+		}
+		for i, item := range items {
+			if err := c.encodeNested(itemTypes[i], item, buf); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "optional", "variadic", "multi":
+		return fmt.Errorf("%s can only be used as a top level argument", t)
+	}
+
+	typeDef, found := c.abi.TypeDef(t.name)
+	if !found {
+		return fmt.Errorf("unknown type %s", t.name)
+	}
+
+	if typeDef.Type == types.AbiStruct {
+		fields, err := toFields(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+		return c.encodeFields(t.name, typeDef.Fields, fields, buf)
+	}
+
+	variant, err := findVariant(t.name, typeDef, value)
+	if err != nil {
+		return err
+	}
+	buf.WriteByte(byte(variant.Discriminant))
+
+	var fields map[string]interface{}
+	if enum, ok := toEnumValue(value); ok {
+		fields = enum.Fields
+	}
+	return c.encodeFields(t.name+"::"+variant.Name, variant.Fields, fields, buf)
+}
+
+// encodeFields encodes the fields of a struct or enum variant in their declared order
+func (c *Codec) encodeFields(owner string, declared []types.AbiField, fields map[string]interface{}, buf *bytes.Buffer) error {
+	for _, field := range declared {
+		value, found := fields[field.Name]
+		if !found {
+			return fmt.Errorf("%s: missing field %s", owner, field.Name)
+		}
+		fieldType, err := parseType(field.Type)
+		if err != nil {
+			return err
+		}
+		if err := c.encodeNested(fieldType, value, buf); err != nil {
+			return fmt.Errorf("%s.%s: %w", owner, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// isSimpleEnum returns whether an enum has no variant with fields
+func isSimpleEnum(typeDef *types.AbiTypeDef) bool {
+	if typeDef.Type != types.AbiEnum && typeDef.Type != types.AbiExplicitEnum {
+		return false
+	}
+	for _, variant := range typeDef.Variants {
+		if len(variant.Fields) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// findVariant returns the enum variant designated by a value: an EnumValue, a variant name or a discriminant
+func findVariant(name string, typeDef *types.AbiTypeDef, value interface{}) (*types.AbiEnumVariant, error) {
+	if typeDef.Type != types.AbiEnum && typeDef.Type != types.AbiExplicitEnum {
+		return nil, fmt.Errorf("type %s is not an enum", name)
+	}
+
+	variantName := ""
+	discriminant := -1
+	if enum, ok := toEnumValue(value); ok {
+		variantName = enum.Name
+		if variantName == "" {
+			discriminant = enum.Discriminant
+		}
+	} else if s, ok := value.(string); ok {
+		variantName = s
+	} else if n, err := toBigInt(value); err == nil && n.IsInt64() {
+		discriminant = int(n.Int64())
+	} else {
+		return nil, fmt.Errorf("invalid %s value %v", name, value)
+	}
+
+	for i, variant := range typeDef.Variants {
+		if variant.Name == variantName || (variantName == "" && variant.Discriminant == discriminant) {
+			return &typeDef.Variants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown %s variant %v", name, value)
+}
+
+// toEnumValue converts the supported enum representations with fields into an EnumValue
+func toEnumValue(value interface{}) (*EnumValue, bool) {
+	switch v := value.(type) {
+	case EnumValue:
+		return &v, true
+	case *EnumValue:
+		return v, v != nil
+	case map[string]interface{}:
+		name, ok := v["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		fields, _ := v["fields"].(map[string]interface{})
+		return &EnumValue{Name: name, Fields: fields}, true
+	}
+	return nil, false
+}
+
+// toBigInt converts a numeric value or its decimal string representation to a big integer
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("invalid number: nil")
+		}
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case json.Number:
+		return toBigInt(string(v))
+	case string:
+		n, ok := new(big.Int).SetString(strings.TrimSpace(v), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", v)
+		}
+		return n, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return nil, fmt.Errorf("invalid integer %v", v)
+		}
+		n, _ := big.NewFloat(v).Int(nil)
+		return n, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("invalid number %v", value)
+}
+
+// checkIntRange checks that a number fits in an integer type
+func checkIntRange(name string, n *big.Int) error {
+	if !isSigned(name) && n.Sign() < 0 {
+		return fmt.Errorf("negative value %s for %s", n, name)
+	}
+
+	width, fixed := fixedIntWidths[name]
+	if !fixed {
+		return nil
+	}
+
+	bits := uint(width * 8)
+	if isSigned(name) {
+		limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+		if n.Cmp(new(big.Int).Neg(limit)) < 0 || n.Cmp(limit) >= 0 {
+			return fmt.Errorf("value %s overflows %s", n, name)
+		}
+		return nil
+	}
+	if n.BitLen() > int(bits) {
+		return fmt.Errorf("value %s overflows %s", n, name)
+	}
+	return nil
+}
+
+// signedBytes returns the two's complement encoding of a number, on width bytes or on the fewest bytes if width is 0
+func signedBytes(n *big.Int, width int) []byte {
+	if width == 0 {
+		if n.Sign() == 0 {
+			return []byte{}
+		}
+		// the fewest bytes that keep the sign bit
+		width = (n.BitLen() + 8) / 8
+		if n.Sign() < 0 {
+			width = (new(big.Int).Not(n).BitLen() + 8) / 8
+		}
+	}
+
+	if n.Sign() >= 0 {
+		return leftPad(n.Bytes(), width)
+	}
+	modulus := new(big.Int).Lsh(big.NewInt(1), uint(width*8))
+	return leftPad(new(big.Int).Add(modulus, n).Bytes(), width)
+}
+
+// leftPad pads data with leading zeros to the width
+func leftPad(data []byte, width int) []byte {
+	if len(data) >= width {
+		return data
+	}
+	padded := make([]byte, width)
+	copy(padded[width-len(data):], data)
+	return padded
+}
+
+// writeLength writes the 4 bytes length prefix of nested buffers and lists
+func writeLength(buf *bytes.Buffer, length int) {
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], uint32(length))
+	buf.Write(prefix[:])
+}
+
+// toBool converts a bool or its string representation
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("invalid bool %v", value)
+}
+
+// toBytes converts a string or byte slice to bytes
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+	return nil, fmt.Errorf("invalid bytes %v", value)
+}
+
+// toFixedBytes converts a byte slice or a hex string to bytes of the given size
+func toFixedBytes(value interface{}, size int) ([]byte, error) {
+	data, ok := value.([]byte)
+	if s, isString := value.(string); isString {
+		decoded, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex %q", s)
+		}
+		data, ok = decoded, true
+	}
+	if !ok || len(data) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %v", size, value)
+	}
+	return data, nil
+}
+
+// toAddress converts a bech32 address or a public key to a public key
+func toAddress(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return address.Decode(v)
+	case []byte:
+		if len(v) == address.PubKeyLength {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid address %v", value)
+}
+
+// toList converts any slice or array to a list of values
+func toList(value interface{}) ([]interface{}, error) {
+	if items, ok := value.([]interface{}); ok {
+		return items, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list, got %v", value)
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, nil
+}
+
+// toFields converts a struct value keyed by field names
+func toFields(value interface{}) (map[string]interface{}, error) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected fields keyed by name, got %v", value)
+	}
+	return fields, nil
+}
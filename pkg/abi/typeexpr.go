@@ -0,0 +1,145 @@
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// typeExpr represents a parsed ABI type expression, e.g. List<Option<u64>>
+type typeExpr struct {
+	name string
+	args []*typeExpr
+	size int // number of items of fixed size arrays
+}
+
+// String returns the type expression in the ABI notation
+func (t *typeExpr) String() string {
+	name := t.name
+	if name == "array" {
+		name = fmt.Sprintf("array%d", t.size)
+	}
+	if len(t.args) == 0 {
+		return name
+	}
+
+	args := make([]string, len(t.args))
+	for i, arg := range t.args {
+		args[i] = arg.String()
+	}
+	return name + "<" + strings.Join(args, ",") + ">"
+}
+
+// parseType parses an ABI type expression
+func parseType(expr string) (*typeExpr, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, fmt.Errorf("empty type")
+	}
+
+	open := strings.Index(expr, "<")
+	if open == -1 {
+		return newTypeExpr(expr, nil)
+	}
+	if !strings.HasSuffix(expr, ">") {
+		return nil, fmt.Errorf("invalid type %s", expr)
+	}
+
+	var args []*typeExpr
+	for _, part := range splitTypeArgs(expr[open+1 : len(expr)-1]) {
+		arg, err := parseType(part)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+
+	return newTypeExpr(strings.TrimSpace(expr[:open]), args)
+}
+
+// newTypeExpr creates a type expression, checking the number of generic arguments
+func newTypeExpr(name string, args []*typeExpr) (*typeExpr, error) {
+	t := &typeExpr{name: name, args: args}
+
+	if strings.HasPrefix(name, "array") && name != "array" {
+		size, err := strconv.Atoi(strings.TrimPrefix(name, "array"))
+		if err == nil {
+			if size < 0 {
+				return nil, fmt.Errorf("type %s has a negative size", name)
+			}
+			t.name = "array"
+			t.size = size
+		}
+	}
+
+	expected := -1
+	switch t.name {
+	case "Option", "optional", "List", "vec", "variadic", "array":
+		expected = 1
+	case "tuple", "multi":
+		if len(args) == 0 {
+			return nil, fmt.Errorf("type %s requires type arguments", name)
+		}
+	default:
+		expected = 0
+	}
+	if expected >= 0 && len(args) != expected {
+		return nil, fmt.Errorf("type %s expects %d type arguments, got %d", name, expected, len(args))
+	}
+
+	return t, nil
+}
+
+// splitTypeArgs splits generic arguments on the commas that are not nested in another generic
+func splitTypeArgs(args string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range args {
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, args[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, args[start:])
+}
+
+// fixedIntWidths maps the fixed size integer types to their nested encoding width in bytes
+var fixedIntWidths = map[string]int{
+	"u8": 1, "u16": 2, "u32": 4, "u64": 8, "usize": 4,
+	"i8": 1, "i16": 2, "i32": 4, "i64": 8, "isize": 4,
+}
+
+// isSigned returns whether an integer type is signed
+func isSigned(name string) bool {
+	return strings.HasPrefix(name, "i") || name == "BigInt"
+}
+
+// isBytesType returns whether the type is encoded as a length prefixed byte buffer
+func isBytesType(name string) bool {
+	switch name {
+	case "bytes", "ManagedBuffer", "BoxedBytes", "utf-8 string", "String", "TokenIdentifier", "EgldOrEsdtTokenIdentifier":
+		return true
+	}
+	return false
+}
+
+// isStringType returns whether a byte buffer type is decoded as a string
+func isStringType(name string) bool {
+	switch name {
+	case "utf-8 string", "String", "TokenIdentifier", "EgldOrEsdtTokenIdentifier":
+		return true
+	}
+	return false
+}
+
+// isMultiType returns whether the type spans any number of top level arguments
+func isMultiType(name string) bool {
+	return name == "variadic" || name == "multi" || name == "optional"
+}
@@ -0,0 +1,129 @@
+package abi
+
+import (
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// warpWrapperTypes lists the warp argument types that wrap another type, e.g. "option:uint64"
+var warpWrapperTypes = map[string]bool{
+	"option":   true,
+	"optional": true,
+	"list":     true,
+	"variadic": true,
+}
+
+// WarpArgType returns the type of a warp argument, e.g. "option:uint64" for "option:uint64:5",
+// or "" if the argument has no type prefix
+func WarpArgType(warpArg string) string {
+	separator := constants.WarpConstants.ArgParamsSeparator
+	parts := strings.Split(warpArg, separator)
+
+	var typeParts []string
+	for _, part := range parts[:len(parts)-1] {
+		if !warpArgTypes[part] && part != string(types.HexInputType) {
+			break
+		}
+		typeParts = append(typeParts, part)
+		if !warpWrapperTypes[part] {
+			break
+		}
+	}
+	return strings.Join(typeParts, separator)
+}
+
+// AcceptsWarpType returns whether values of a warp argument or input type, e.g. "biguint" or
+// "option:uint64", can be given to an ABI type. Hex values are passed as raw encoded bytes and
+// fit any type, as do warp types the SDK does not know.
+func (c *Codec) AcceptsWarpType(abiType string, warpType string) (bool, error) {
+	t, err := parseType(abiType)
+	if err != nil {
+		return false, err
+	}
+	return c.acceptsWarpType(t, warpType), nil
+}
+
+// TopLevelTypes returns the ABI types of the top level arguments a value of an ABI type is given as:
+// one argument per item for multi values, and the type itself for all other types.
+func TopLevelTypes(abiType string) ([]string, error) {
+	t, err := parseType(abiType)
+	if err != nil {
+		return nil, err
+	}
+	if t.name != "multi" {
+		return []string{t.String()}, nil
+	}
+
+	var itemTypes []string
+	for _, item := range t.args {
+		nested, err := TopLevelTypes(item.String())
+		if err != nil {
+			return nil, err
+		}
+		itemTypes = append(itemTypes, nested...)
+	}
+	return itemTypes, nil
+}
+
+// acceptsWarpType returns whether values of a warp type can be given to the ABI type
+func (c *Codec) acceptsWarpType(t *typeExpr, warpType string) bool {
+	separator := constants.WarpConstants.ArgParamsSeparator
+	name, inner, _ := strings.Cut(warpType, separator)
+
+	switch name {
+	case "option", "optional":
+		if t.name != "Option" && t.name != "optional" {
+			return false
+		}
+		return inner == "" || c.acceptsWarpType(t.args[0], inner)
+	case "list", "variadic":
+		if t.name != "List" && t.name != "vec" && t.name != "array" && t.name != "variadic" {
+			return false
+		}
+		return inner == "" || c.acceptsWarpType(t.args[0], inner)
+	}
+
+	// Plain values are given to the inner type of options and variadic arguments
+	if t.name == "Option" || t.name == "optional" || t.name == "variadic" {
+		return c.acceptsWarpType(t.args[0], warpType)
+	}
+
+	typeDef, custom := c.abi.TypeDef(t.name)
+	switch types.BaseWarpActionInputType(name) {
+	case types.HexInputType:
+		return true
+	case types.StringInputType:
+		return isBytesType(t.name) || (custom && isSimpleEnum(typeDef))
+	case types.Uint8InputType, types.Uint16InputType, types.Uint32InputType, types.Uint64InputType:
+		width, fixed := fixedIntWidths[t.name]
+		if fixed {
+			return width >= warpIntWidths[name] && !isSigned(t.name)
+		}
+		return t.name == "BigUint" || t.name == "BigInt" || (custom && isSimpleEnum(typeDef))
+	case types.BigUintInputType:
+		return t.name == "BigUint" || t.name == "BigInt"
+	case types.BoolInputType:
+		return t.name == "bool"
+	case types.AddressInputType:
+		return t.name == "Address"
+	case types.TokenInputType:
+		return isBytesType(t.name)
+	case types.CodeMetaInputType:
+		return t.name == "CodeMetadata"
+	case types.EsdtInputType, types.NftInputType:
+		return custom && typeDef.Type == types.AbiStruct
+	case "composite":
+		return t.name == "tuple" || t.name == "multi" || custom
+	}
+	return !warpArgTypes[name]
+}
+
+// warpIntWidths maps the fixed size integer warp types to their width in bytes
+var warpIntWidths = map[string]int{
+	string(types.Uint8InputType):  1,
+	string(types.Uint16InputType): 2,
+	string(types.Uint32InputType): 4,
+	string(types.Uint64InputType): 8,
+}
@@ -0,0 +1,170 @@
+// Package address provides encoding and decoding of MultiversX bech32 addresses
+package address
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// HRP is the human-readable part of MultiversX addresses
+const HRP = "erd"
+
+// PubKeyLength is the length in bytes of an account public key
+const PubKeyLength = 32
+
+const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+var generator = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+// Encode encodes a public key as a bech32 address
+func Encode(pubKey []byte) (string, error) {
+	if len(pubKey) != PubKeyLength {
+		return "", fmt.Errorf("address: invalid public key length %d", len(pubKey))
+	}
+
+	data, err := convertBits(pubKey, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	checksum := createChecksum(HRP, data)
+	var sb strings.Builder
+	sb.WriteString(HRP)
+	sb.WriteByte('1')
+	for _, b := range append(data, checksum...) {
+		sb.WriteByte(charset[b])
+	}
+
+	return sb.String(), nil
+}
+
+// Decode decodes a bech32 address into its public key
+func Decode(addr string) ([]byte, error) {
+	if strings.ToLower(addr) != addr && strings.ToUpper(addr) != addr {
+		return nil, errors.New("address: mixed case")
+	}
+	addr = strings.ToLower(addr)
+
+	sep := strings.LastIndexByte(addr, '1')
+	if sep < 1 || sep+7 > len(addr) {
+		return nil, errors.New("address: invalid separator position")
+	}
+
+	hrp := addr[:sep]
+	if hrp != HRP {
+		return nil, fmt.Errorf("address: invalid prefix %q", hrp)
+	}
+
+	data := make([]byte, 0, len(addr)-sep-1)
+	for _, c := range addr[sep+1:] {
+		idx := strings.IndexRune(charset, c)
+		if idx < 0 {
+			return nil, fmt.Errorf("address: invalid character %q", c)
+		}
+		data = append(data, byte(idx))
+	}
+
+	if polymod(append(expandHRP(hrp), data...)) != 1 {
+		return nil, errors.New("address: invalid checksum")
+	}
+
+	pubKey, err := convertBits(data[:len(data)-6], 5, 8, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(pubKey) != PubKeyLength {
+		return nil, fmt.Errorf("address: invalid public key length %d", len(pubKey))
+	}
+
+	return pubKey, nil
+}
+
+// IsValid checks if a string is a valid bech32 address
+func IsValid(addr string) bool {
+	_, err := Decode(addr)
+	return err == nil
+}
+
+// IsSmartContract checks if an address belongs to a smart contract
+func IsSmartContract(addr string) bool {
+	pubKey, err := Decode(addr)
+	if err != nil {
+		return false
+	}
+
+	// Contract addresses start with 8 zero bytes
+	for _, b := range pubKey[:8] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func polymod(values []byte) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func expandHRP(hrp string) []byte {
+	result := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]>>5)
+	}
+	result = append(result, 0)
+	for i := 0; i < len(hrp); i++ {
+		result = append(result, hrp[i]&31)
+	}
+	return result
+}
+
+func createChecksum(hrp string, data []byte) []byte {
+	values := append(expandHRP(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := polymod(values) ^ 1
+
+	checksum := make([]byte, 6)
+	for i := range checksum {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+	return checksum
+}
+
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxValue := uint32(1)<<toBits - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, errors.New("address: invalid data range")
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxValue))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxValue != 0 {
+		return nil, errors.New("address: invalid padding")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package address
+
+import (
+	"testing"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	tests := []struct {
+		name       string
+		address    string
+		isContract bool
+	}{
+		{"Smart contract", "erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3", true},
+		{"User account", "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pubKey, err := Decode(tt.address)
+			if err != nil {
+				t.Fatalf("Decode(%s) error = %v", tt.address, err)
+			}
+
+			encoded, err := Encode(pubKey)
+			if err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			if encoded != tt.address {
+				t.Errorf("Encode(Decode(%s)) = %s", tt.address, encoded)
+			}
+
+			if IsSmartContract(tt.address) != tt.isContract {
+				t.Errorf("IsSmartContract(%s) = %v, expected %v", tt.address, !tt.isContract, tt.isContract)
+			}
+		})
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		address  string
+		expected bool
+	}{
+		{"Valid", "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", true},
+		{"Bad checksum", "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6tq", false},
+		{"Wrong prefix", "bc1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th", false},
+		{"Placeholder", "erd1...", false},
+		{"Empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsValid(tt.address); result != tt.expected {
+				t.Errorf("IsValid(%s) = %v, expected %v", tt.address, result, tt.expected)
+			}
+		})
+	}
+}
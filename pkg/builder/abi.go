@@ -0,0 +1,195 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/cache"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/protocol"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/validator"
+)
+
+// AbiBuilder provides functionality for inscribing contract ABIs and resolving the ABIs referenced by actions
+type AbiBuilder struct {
+	config types.WarpConfig
+	cache  *cache.WarpCache
+}
+
+// NewAbiBuilder creates a new AbiBuilder instance
+func NewAbiBuilder(config types.WarpConfig) *AbiBuilder {
+	return &AbiBuilder{
+		config: config,
+		cache:  cache.NewWarpCache(),
+	}
+}
+
+// CreateInscriptionTransaction creates an unsigned transaction inscribing an ABI on the blockchain.
+// The transaction is sent from the user address to itself with the ABI as data.
+func (b *AbiBuilder) CreateInscriptionTransaction(content types.AbiContents) (*types.Transaction, error) {
+	abi := &types.WarpAbi{
+		Protocol: utils.GetLatestProtocolIdentifier(types.AbiProtocol),
+		Content:  content,
+	}
+	if err := validator.NewWarpValidator(b.config).ValidateAbi(abi); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(abi)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := createInscriptionTransaction(b.config, data)
+	if err != nil {
+		return nil, fmt.Errorf("AbiBuilder: %w", err)
+	}
+
+	return tx, nil
+}
+
+// CreateFromRaw creates an ABI from a raw JSON string.
+// It accepts both ABI inscriptions and plain contract ABI files, which are wrapped under the latest ABI protocol.
+// ABI inscriptions of older protocol versions are upgraded to the current model, see protocol.Upgrade.
+func (b *AbiBuilder) CreateFromRaw(encoded string, validate bool) (*types.WarpAbi, error) {
+	upgraded, err := protocol.Upgrade(types.AbiProtocol, []byte(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("AbiBuilder: %w", err)
+	}
+
+	var abi types.WarpAbi
+	if err := json.Unmarshal(upgraded, &abi); err != nil {
+		return nil, fmt.Errorf("AbiBuilder: invalid ABI: %w", err)
+	}
+
+	if abi.Protocol == "" {
+		abi = types.WarpAbi{Protocol: utils.GetLatestProtocolIdentifier(types.AbiProtocol)}
+		if err := json.Unmarshal([]byte(encoded), &abi.Content); err != nil {
+			return nil, fmt.Errorf("AbiBuilder: invalid ABI: %w", err)
+		}
+	}
+
+	if validate {
+		if err := validator.NewWarpValidator(b.config).ValidateAbi(&abi); err != nil {
+			return nil, err
+		}
+	}
+
+	return &abi, nil
+}
+
+// CreateFromTransaction creates an ABI from a transaction
+func (b *AbiBuilder) CreateFromTransaction(txData string, sender string, timestamp int64, txHash string, validate bool) (*types.WarpAbi, error) {
+	abi, err := b.CreateFromRaw(txData, validate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add metadata
+	abi.Meta = &types.WarpMeta{
+		Hash:      txHash,
+		Creator:   sender,
+		CreatedAt: utils.FormatTimeISO8601(time.Unix(timestamp, 0)),
+	}
+
+	return abi, nil
+}
+
+// CreateFromTransactionHash creates an ABI from the hash of its inscription transaction
+func (b *AbiBuilder) CreateFromTransactionHash(hash string, cacheConfig *types.WarpCacheConfig) (*types.WarpAbi, error) {
+	return b.load(hash, cacheConfig, func() (*types.WarpAbi, error) {
+		tx, err := fetchInscription(b.config, hash)
+		if err != nil {
+			return nil, fmt.Errorf("AbiBuilder: %w", err)
+		}
+		return b.CreateFromTransaction(string(tx.Data), tx.Sender, tx.Timestamp, hash, true)
+	})
+}
+
+// CreateFromURL creates an ABI from a hosted ABI file
+func (b *AbiBuilder) CreateFromURL(abiURL string, cacheConfig *types.WarpCacheConfig) (*types.WarpAbi, error) {
+	return b.load(abiURL, cacheConfig, func() (*types.WarpAbi, error) {
+		resp, err := utils.NewHTTPClient(b.config).Get(abiURL)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("AbiBuilder: failed to get ABI %s: %s", abiURL, resp.Status)
+		}
+
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		return b.CreateFromRaw(string(body), true)
+	})
+}
+
+// Resolve resolves an ABI reference, either "hash:{inscription hash}" or the URL of an ABI file
+func (b *AbiBuilder) Resolve(ref string, cacheConfig *types.WarpCacheConfig) (*types.WarpAbi, error) {
+	hashPrefix := constants.WarpConstants.IdentifierType.Hash + constants.WarpConstants.IdentifierParamSeparator
+
+	switch {
+	case strings.HasPrefix(ref, hashPrefix):
+		return b.CreateFromTransactionHash(strings.TrimPrefix(ref, hashPrefix), cacheConfig)
+	case strings.HasPrefix(ref, constants.WarpConstants.HTTPProtocolPrefix):
+		return b.CreateFromURL(ref, cacheConfig)
+	default:
+		return nil, fmt.Errorf("AbiBuilder: invalid ABI reference: %s", ref)
+	}
+}
+
+// ResolveForAction resolves the ABI referenced by a contract or query action.
+// It returns nil if the action does not reference an ABI.
+func (b *AbiBuilder) ResolveForAction(action types.WarpAction, cacheConfig *types.WarpCacheConfig) (*types.WarpAbi, error) {
+	var ref *string
+	switch a := action.(type) {
+	case types.WarpContractAction:
+		ref = a.ABI
+	case types.WarpQueryAction:
+		ref = a.ABI
+	}
+
+	if ref == nil || *ref == "" {
+		return nil, nil
+	}
+	return b.Resolve(*ref, cacheConfig)
+}
+
+// load returns the cached ABI for the reference, or fetches and caches it
+func (b *AbiBuilder) load(ref string, cacheConfig *types.WarpCacheConfig, fetch func() (*types.WarpAbi, error)) (abi *types.WarpAbi, err error) {
+	done := observability.Track(b.config.Observer, observability.OpAbiFetch, slog.String("ref", ref))
+	defer func() { done(err) }()
+
+	// Check cache
+	if cacheConfig != nil {
+		cachedAbi := b.cache.Get(cache.CacheKey.Abi(ref))
+		observability.RecordCacheLookup(b.config.Observer, "abi", cachedAbi != nil)
+		if cachedAbi != nil {
+			return cachedAbi.(*types.WarpAbi), nil
+		}
+	}
+
+	abi, err = fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the ABI if caching is enabled
+	if cacheConfig != nil && cacheConfig.TTL > 0 {
+		b.cache.Set(cache.CacheKey.Abi(ref), abi, cacheConfig.TTL)
+	}
+
+	return abi, nil
+}
@@ -0,0 +1,97 @@
+package builder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestAbiCreateInscriptionTransaction(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	tx, err := NewAbiBuilder(server.Config()).CreateInscriptionTransaction(warptest.FixtureAbi().Content)
+	if err != nil {
+		t.Fatalf("CreateInscriptionTransaction() error = %v", err)
+	}
+	if tx.Sender != warptest.Creator || tx.Receiver != warptest.Creator {
+		t.Errorf("Sender, Receiver = %s, %s, expected a self transfer", tx.Sender, tx.Receiver)
+	}
+
+	var inscribed types.WarpAbi
+	if err := json.Unmarshal(tx.Data, &inscribed); err != nil {
+		t.Fatalf("Data is not an ABI: %v", err)
+	}
+	if inscribed.Protocol != "abi-0.0.2" || len(inscribed.Content.Endpoints) != 2 {
+		t.Errorf("Data = %s, expected the fixture ABI under the latest protocol", tx.Data)
+	}
+
+	if _, err := NewAbiBuilder(server.Config()).CreateInscriptionTransaction(types.AbiContents{}); err == nil {
+		t.Error("CreateInscriptionTransaction() error = nil, expected an ABI without endpoints to be rejected")
+	}
+}
+
+func TestAbiResolve(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	abiBuilder := NewAbiBuilder(server.Config())
+	cacheConfig := &types.WarpCacheConfig{TTL: 60}
+
+	byHash, err := abiBuilder.Resolve("hash:"+server.AbiHash, cacheConfig)
+	if err != nil {
+		t.Fatalf("Resolve() hash error = %v", err)
+	}
+	if byHash.Meta == nil || byHash.Meta.Hash != server.AbiHash || byHash.Meta.Creator != warptest.Creator {
+		t.Errorf("Meta = %v, expected hash %s by %s", byHash.Meta, server.AbiHash, warptest.Creator)
+	}
+
+	byURL, err := abiBuilder.Resolve(server.AbiURL(server.AbiHash), cacheConfig)
+	if err != nil {
+		t.Fatalf("Resolve() URL error = %v", err)
+	}
+	if byURL.Protocol != "abi-0.0.2" || len(byURL.Content.Endpoints) != len(byHash.Content.Endpoints) {
+		t.Errorf("Resolve() URL = %v, expected the fixture ABI", byURL)
+	}
+
+	if _, err := abiBuilder.Resolve("alias:staking", nil); err == nil {
+		t.Error("Resolve() error = nil, expected an invalid reference")
+	}
+
+	// Resolved ABIs are served from the cache once the chain API is gone
+	server.Close()
+	cached, err := abiBuilder.Resolve("hash:"+server.AbiHash, cacheConfig)
+	if err != nil || cached != byHash {
+		t.Errorf("Resolve() cached = %v, %v, expected the cached ABI", cached, err)
+	}
+}
+
+func TestAbiResolveForAction(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	abiBuilder := NewAbiBuilder(server.Config())
+
+	ref := "hash:" + server.AbiHash
+	query := types.WarpQueryAction{
+		Type:    types.QueryActionType,
+		Label:   "Get stake",
+		Address: warptest.RegistryContract,
+		Func:    "getStake",
+		ABI:     &ref,
+	}
+	abi, err := abiBuilder.ResolveForAction(query, nil)
+	if err != nil {
+		t.Fatalf("ResolveForAction() error = %v", err)
+	}
+	if abi == nil || abi.Meta.Hash != server.AbiHash {
+		t.Errorf("ResolveForAction() = %v, expected the fixture ABI", abi)
+	}
+
+	abi, err = abiBuilder.ResolveForAction(warptest.FixtureWarp().Actions[0], nil)
+	if err != nil || abi != nil {
+		t.Errorf("ResolveForAction() = %v, %v, expected nil for an action without ABI", abi, err)
+	}
+}
@@ -0,0 +1,554 @@
+package builder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/abi"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// maxLabelLength is the maximum length of action labels allowed by the warp schema
+const maxLabelLength = 25
+
+// ContractActionBuilder builds a contract action, e.g. Contract(address).Func("stake").Arg("biguint:1").GasLimit(5000000)
+type ContractActionBuilder struct {
+	warp   *WarpBuilder
+	action types.WarpContractAction
+	inputs []*InputBuilder
+}
+
+// Contract creates a builder for an action calling a function of a smart contract
+func (b *WarpBuilder) Contract(contractAddress string) *ContractActionBuilder {
+	return &ContractActionBuilder{
+		warp:   b,
+		action: types.WarpContractAction{Type: types.ContractActionType, Address: contractAddress, Args: []string{}},
+	}
+}
+
+// Label sets the label of the action
+func (a *ContractActionBuilder) Label(label string) *ContractActionBuilder {
+	a.action.Label = label
+	return a
+}
+
+// Description sets the description of the action
+func (a *ContractActionBuilder) Description(description string) *ContractActionBuilder {
+	a.action.Description = &description
+	return a
+}
+
+// Func sets the contract function called by the action
+func (a *ContractActionBuilder) Func(fn string) *ContractActionBuilder {
+	a.action.Func = &fn
+	return a
+}
+
+// Arg adds a typed argument, e.g. "biguint:1" or "address:erd1..."
+func (a *ContractActionBuilder) Arg(arg string) *ContractActionBuilder {
+	a.action.Args = append(a.action.Args, arg)
+	return a
+}
+
+// Value sets the amount of EGLD sent with the call
+func (a *ContractActionBuilder) Value(value string) *ContractActionBuilder {
+	a.action.Value = &value
+	return a
+}
+
+// GasLimit sets the gas limit of the call
+func (a *ContractActionBuilder) GasLimit(gasLimit int) *ContractActionBuilder {
+	a.action.GasLimit = gasLimit
+	return a
+}
+
+// TokenTransfer adds a token sent with the call
+func (a *ContractActionBuilder) TokenTransfer(token string, nonce int, amount string) *ContractActionBuilder {
+	a.action.Transfers = append(a.action.Transfers, tokenTransfer(token, nonce, amount))
+	return a
+}
+
+// ABI sets the URL of the contract ABI
+func (a *ContractActionBuilder) ABI(abiURL string) *ContractActionBuilder {
+	a.action.ABI = &abiURL
+	return a
+}
+
+// Input adds an input to the action
+func (a *ContractActionBuilder) Input(input *InputBuilder) *ContractActionBuilder {
+	a.inputs = append(a.inputs, input)
+	return a
+}
+
+// Next sets the warp or URL opened after the action
+func (a *ContractActionBuilder) Next(next string) *ContractActionBuilder {
+	a.action.Next = &next
+	return a
+}
+
+// Build validates and returns the action
+func (a *ContractActionBuilder) Build() (types.WarpContractAction, error) {
+	action := a.action
+	inputs, err := buildAction(action.Type, action.Label, a.inputs)
+	if err != nil {
+		return types.WarpContractAction{}, err
+	}
+	action.Inputs = inputs
+
+	if !isContractAddress(action.Address) {
+		return types.WarpContractAction{}, actionError(action.Type, "invalid contract address %q", action.Address)
+	}
+	if action.Func == nil || *action.Func == "" {
+		return types.WarpContractAction{}, actionError(action.Type, "func is required")
+	}
+	if action.GasLimit <= 0 {
+		return types.WarpContractAction{}, actionError(action.Type, "gas limit is required")
+	}
+	if err := checkArgs(action.Type, action.Args); err != nil {
+		return types.WarpContractAction{}, err
+	}
+	if err := checkTransfers(action.Type, action.Transfers); err != nil {
+		return types.WarpContractAction{}, err
+	}
+
+	return action, nil
+}
+
+// Add builds the action and adds it to the warp. Errors are returned by WarpBuilder.Build.
+func (a *ContractActionBuilder) Add() *WarpBuilder {
+	action, err := a.Build()
+	return a.warp.addBuilt(action, err)
+}
+
+// TransferActionBuilder builds an action sending EGLD or tokens, e.g. Transfer().To(receiver).Value("1")
+type TransferActionBuilder struct {
+	warp   *WarpBuilder
+	action types.WarpTransferAction
+	inputs []*InputBuilder
+}
+
+// Transfer creates a builder for an action sending EGLD or tokens
+func (b *WarpBuilder) Transfer() *TransferActionBuilder {
+	return &TransferActionBuilder{
+		warp:   b,
+		action: types.WarpTransferAction{Type: types.TransferActionType},
+	}
+}
+
+// Label sets the label of the action
+func (a *TransferActionBuilder) Label(label string) *TransferActionBuilder {
+	a.action.Label = label
+	return a
+}
+
+// Description sets the description of the action
+func (a *TransferActionBuilder) Description(description string) *TransferActionBuilder {
+	a.action.Description = &description
+	return a
+}
+
+// To sets the receiver of the transfer
+func (a *TransferActionBuilder) To(receiver string) *TransferActionBuilder {
+	a.action.Address = &receiver
+	return a
+}
+
+// Arg adds a typed argument to the transfer data
+func (a *TransferActionBuilder) Arg(arg string) *TransferActionBuilder {
+	a.action.Args = append(a.action.Args, arg)
+	return a
+}
+
+// Value sets the amount of EGLD sent
+func (a *TransferActionBuilder) Value(value string) *TransferActionBuilder {
+	a.action.Value = &value
+	return a
+}
+
+// TokenTransfer adds a token sent with the transfer
+func (a *TransferActionBuilder) TokenTransfer(token string, nonce int, amount string) *TransferActionBuilder {
+	a.action.Transfers = append(a.action.Transfers, tokenTransfer(token, nonce, amount))
+	return a
+}
+
+// Input adds an input to the action
+func (a *TransferActionBuilder) Input(input *InputBuilder) *TransferActionBuilder {
+	a.inputs = append(a.inputs, input)
+	return a
+}
+
+// Next sets the warp or URL opened after the action
+func (a *TransferActionBuilder) Next(next string) *TransferActionBuilder {
+	a.action.Next = &next
+	return a
+}
+
+// Build validates and returns the action. The receiver is set with To or by a receiver input.
+func (a *TransferActionBuilder) Build() (types.WarpTransferAction, error) {
+	action := a.action
+	inputs, err := buildAction(action.Type, action.Label, a.inputs)
+	if err != nil {
+		return types.WarpTransferAction{}, err
+	}
+	action.Inputs = inputs
+
+	if action.Address == nil && !hasPosition(inputs, types.ReceiverPosition) {
+		return types.WarpTransferAction{}, actionError(action.Type, "receiver is required")
+	}
+	if action.Address != nil && !isAddress(*action.Address) {
+		return types.WarpTransferAction{}, actionError(action.Type, "invalid receiver %q", *action.Address)
+	}
+	if err := checkArgs(action.Type, action.Args); err != nil {
+		return types.WarpTransferAction{}, err
+	}
+	if err := checkTransfers(action.Type, action.Transfers); err != nil {
+		return types.WarpTransferAction{}, err
+	}
+
+	return action, nil
+}
+
+// Add builds the action and adds it to the warp. Errors are returned by WarpBuilder.Build.
+func (a *TransferActionBuilder) Add() *WarpBuilder {
+	action, err := a.Build()
+	return a.warp.addBuilt(action, err)
+}
+
+// QueryActionBuilder builds an action reading a smart contract view, e.g. Query(address, "getStake")
+type QueryActionBuilder struct {
+	warp   *WarpBuilder
+	action types.WarpQueryAction
+	inputs []*InputBuilder
+}
+
+// Query creates a builder for an action reading a view function of a smart contract
+func (b *WarpBuilder) Query(contractAddress string, fn string) *QueryActionBuilder {
+	return &QueryActionBuilder{
+		warp:   b,
+		action: types.WarpQueryAction{Type: types.QueryActionType, Address: contractAddress, Func: fn, Args: []string{}},
+	}
+}
+
+// Label sets the label of the action
+func (a *QueryActionBuilder) Label(label string) *QueryActionBuilder {
+	a.action.Label = label
+	return a
+}
+
+// Description sets the description of the action
+func (a *QueryActionBuilder) Description(description string) *QueryActionBuilder {
+	a.action.Description = &description
+	return a
+}
+
+// Arg adds a typed argument, e.g. "address:erd1..."
+func (a *QueryActionBuilder) Arg(arg string) *QueryActionBuilder {
+	a.action.Args = append(a.action.Args, arg)
+	return a
+}
+
+// ABI sets the URL of the contract ABI
+func (a *QueryActionBuilder) ABI(abiURL string) *QueryActionBuilder {
+	a.action.ABI = &abiURL
+	return a
+}
+
+// Input adds an input to the action
+func (a *QueryActionBuilder) Input(input *InputBuilder) *QueryActionBuilder {
+	a.inputs = append(a.inputs, input)
+	return a
+}
+
+// Next sets the warp or URL opened after the action
+func (a *QueryActionBuilder) Next(next string) *QueryActionBuilder {
+	a.action.Next = &next
+	return a
+}
+
+// Build validates and returns the action
+func (a *QueryActionBuilder) Build() (types.WarpQueryAction, error) {
+	action := a.action
+	inputs, err := buildAction(action.Type, action.Label, a.inputs)
+	if err != nil {
+		return types.WarpQueryAction{}, err
+	}
+	action.Inputs = inputs
+
+	if !isContractAddress(action.Address) {
+		return types.WarpQueryAction{}, actionError(action.Type, "invalid contract address %q", action.Address)
+	}
+	if action.Func == "" {
+		return types.WarpQueryAction{}, actionError(action.Type, "func is required")
+	}
+	if err := checkArgs(action.Type, action.Args); err != nil {
+		return types.WarpQueryAction{}, err
+	}
+
+	return action, nil
+}
+
+// Add builds the action and adds it to the warp. Errors are returned by WarpBuilder.Build.
+func (a *QueryActionBuilder) Add() *WarpBuilder {
+	action, err := a.Build()
+	return a.warp.addBuilt(action, err)
+}
+
+// CollectActionBuilder builds an action sending the inputs to an HTTP endpoint, e.g. Collect().URL("https://...")
+type CollectActionBuilder struct {
+	warp   *WarpBuilder
+	action types.WarpCollectAction
+	inputs []*InputBuilder
+}
+
+// Collect creates a builder for an action sending the inputs to an HTTP endpoint, with POST by default
+func (b *WarpBuilder) Collect() *CollectActionBuilder {
+	action := types.WarpCollectAction{Type: types.CollectActionType}
+	action.Destination.Method = types.POST
+	return &CollectActionBuilder{warp: b, action: action}
+}
+
+// Label sets the label of the action
+func (a *CollectActionBuilder) Label(label string) *CollectActionBuilder {
+	a.action.Label = label
+	return a
+}
+
+// Description sets the description of the action
+func (a *CollectActionBuilder) Description(description string) *CollectActionBuilder {
+	a.action.Description = &description
+	return a
+}
+
+// URL sets the endpoint receiving the inputs
+func (a *CollectActionBuilder) URL(destination string) *CollectActionBuilder {
+	a.action.Destination.URL = destination
+	return a
+}
+
+// Method sets the HTTP method used to send the inputs, GET or POST
+func (a *CollectActionBuilder) Method(method types.RequestMethod) *CollectActionBuilder {
+	a.action.Destination.Method = method
+	return a
+}
+
+// Header adds an HTTP header sent to the endpoint
+func (a *CollectActionBuilder) Header(name string, value string) *CollectActionBuilder {
+	if a.action.Destination.Headers == nil {
+		a.action.Destination.Headers = make(map[string]string)
+	}
+	a.action.Destination.Headers[name] = value
+	return a
+}
+
+// Input adds an input to the action
+func (a *CollectActionBuilder) Input(input *InputBuilder) *CollectActionBuilder {
+	a.inputs = append(a.inputs, input)
+	return a
+}
+
+// Next sets the warp or URL opened after the action
+func (a *CollectActionBuilder) Next(next string) *CollectActionBuilder {
+	a.action.Next = &next
+	return a
+}
+
+// Build validates and returns the action
+func (a *CollectActionBuilder) Build() (types.WarpCollectAction, error) {
+	action := a.action
+	inputs, err := buildAction(action.Type, action.Label, a.inputs)
+	if err != nil {
+		return types.WarpCollectAction{}, err
+	}
+	action.Inputs = inputs
+
+	if !isHTTPURL(action.Destination.URL) {
+		return types.WarpCollectAction{}, actionError(action.Type, "invalid destination URL %q", action.Destination.URL)
+	}
+	if action.Destination.Method != types.GET && action.Destination.Method != types.POST {
+		return types.WarpCollectAction{}, actionError(action.Type, "invalid method %q", action.Destination.Method)
+	}
+
+	return action, nil
+}
+
+// Add builds the action and adds it to the warp. Errors are returned by WarpBuilder.Build.
+func (a *CollectActionBuilder) Add() *WarpBuilder {
+	action, err := a.Build()
+	return a.warp.addBuilt(action, err)
+}
+
+// LinkActionBuilder builds an action opening a URL, e.g. Link("https://...").Label("Docs")
+type LinkActionBuilder struct {
+	warp   *WarpBuilder
+	action types.WarpLinkAction
+	inputs []*InputBuilder
+}
+
+// Link creates a builder for an action opening a URL
+func (b *WarpBuilder) Link(link string) *LinkActionBuilder {
+	return &LinkActionBuilder{
+		warp:   b,
+		action: types.WarpLinkAction{Type: types.LinkActionType, URL: link},
+	}
+}
+
+// Label sets the label of the action
+func (a *LinkActionBuilder) Label(label string) *LinkActionBuilder {
+	a.action.Label = label
+	return a
+}
+
+// Description sets the description of the action
+func (a *LinkActionBuilder) Description(description string) *LinkActionBuilder {
+	a.action.Description = &description
+	return a
+}
+
+// Input adds an input to the action
+func (a *LinkActionBuilder) Input(input *InputBuilder) *LinkActionBuilder {
+	a.inputs = append(a.inputs, input)
+	return a
+}
+
+// Build validates and returns the action
+func (a *LinkActionBuilder) Build() (types.WarpLinkAction, error) {
+	action := a.action
+	inputs, err := buildAction(action.Type, action.Label, a.inputs)
+	if err != nil {
+		return types.WarpLinkAction{}, err
+	}
+	action.Inputs = inputs
+
+	if !isHTTPURL(action.URL) {
+		return types.WarpLinkAction{}, actionError(action.Type, "invalid URL %q", action.URL)
+	}
+
+	return action, nil
+}
+
+// Add builds the action and adds it to the warp. Errors are returned by WarpBuilder.Build.
+func (a *LinkActionBuilder) Add() *WarpBuilder {
+	action, err := a.Build()
+	return a.warp.addBuilt(action, err)
+}
+
+// addBuilt adds a built action to the pending warp, or records the error returned by Build
+func (b *WarpBuilder) addBuilt(action types.WarpAction, err error) *WarpBuilder {
+	if err != nil {
+		b.actionErrors = append(b.actionErrors, err)
+		return b
+	}
+	return b.AddAction(action)
+}
+
+// buildAction checks the label of an action and builds its inputs, which may not share a position
+func buildAction(actionType types.WarpActionType, label string, builders []*InputBuilder) ([]types.WarpActionInput, error) {
+	if label == "" {
+		return nil, actionError(actionType, "label is required")
+	}
+	if utf8.RuneCountInString(label) > maxLabelLength {
+		return nil, actionError(actionType, "label %q is longer than %d characters", label, maxLabelLength)
+	}
+
+	var inputs []types.WarpActionInput
+	positions := make(map[types.WarpActionInputPosition]string)
+	for _, builder := range builders {
+		input, err := builder.Build()
+		if err != nil {
+			return nil, err
+		}
+		if other, found := positions[input.Position]; found && input.Source == types.FieldSource {
+			return nil, actionError(actionType, "inputs %s and %s share the position %s", other, input.Name, input.Position)
+		}
+		if input.Source == types.FieldSource {
+			positions[input.Position] = input.Name
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// checkArgs checks that each argument has a known type prefix and that hex arguments are valid
+func checkArgs(actionType types.WarpActionType, args []string) error {
+	separator := constants.WarpConstants.ArgParamsSeparator
+	for _, arg := range args {
+		argType := abi.WarpArgType(arg)
+		if argType == "" {
+			return actionError(actionType, "argument %q has no type, e.g. biguint:1", arg)
+		}
+		value := strings.TrimPrefix(arg, argType+separator)
+		if argType == string(types.HexInputType) && !hasPlaceholder(value) {
+			if _, err := hex.DecodeString(value); err != nil {
+				return actionError(actionType, "argument %q is not valid hex", arg)
+			}
+		}
+	}
+	return nil
+}
+
+// checkTransfers checks that each token transfer has a token and an amount
+func checkTransfers(actionType types.WarpActionType, transfers []types.WarpContractActionTransfer) error {
+	for _, transfer := range transfers {
+		if transfer.Token == "" {
+			return actionError(actionType, "transfer token is required")
+		}
+		if transfer.Amount == nil || *transfer.Amount == "" {
+			return actionError(actionType, "the transfer of %s needs an amount", transfer.Token)
+		}
+	}
+	return nil
+}
+
+// tokenTransfer creates a token transfer, without nonce for fungible tokens
+func tokenTransfer(token string, nonce int, amount string) types.WarpContractActionTransfer {
+	transfer := types.WarpContractActionTransfer{Token: token, Amount: &amount}
+	if nonce > 0 {
+		transfer.Nonce = &nonce
+	}
+	return transfer
+}
+
+// hasPosition returns whether one of the inputs is at the position
+func hasPosition(inputs []types.WarpActionInput, position types.WarpActionInputPosition) bool {
+	for _, input := range inputs {
+		if input.Position == position {
+			return true
+		}
+	}
+	return false
+}
+
+// hasPlaceholder returns whether a value contains a {{VAR}} placeholder, resolved when the warp is used
+func hasPlaceholder(value string) bool {
+	return utils.VarPlaceholderPattern.MatchString(value)
+}
+
+// isAddress returns whether a value is a valid address or a placeholder
+func isAddress(value string) bool {
+	return hasPlaceholder(value) || address.IsValid(value)
+}
+
+// isContractAddress returns whether a value is a smart contract address or a placeholder
+func isContractAddress(value string) bool {
+	return hasPlaceholder(value) || address.IsSmartContract(value)
+}
+
+// isHTTPURL returns whether a value is an absolute http or https URL, or contains a placeholder
+func isHTTPURL(value string) bool {
+	if hasPlaceholder(value) {
+		return true
+	}
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "https" || parsed.Scheme == "http") && parsed.Host != ""
+}
+
+// actionError formats an error of an action builder
+func actionError(actionType types.WarpActionType, format string, args ...interface{}) error {
+	return fmt.Errorf("WarpBuilder: %s action: %s", actionType, fmt.Sprintf(format, args...))
+}
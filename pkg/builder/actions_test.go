@@ -0,0 +1,156 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestFluentActions(t *testing.T) {
+	warpBuilder := NewWarpBuilder(types.WarpConfig{})
+	warp, err := warpBuilder.SetName("stake").SetTitle("Stake").
+		Contract(warptest.RegistryContract).Label("Stake").Func("stake").Arg("biguint:1").GasLimit(5000000).
+		Input(Input("amount").Type("biguint").Position(types.ValuePosition).Scale(18).Max(1000).Required()).
+		Add().
+		Transfer().Label("Tip").To(warptest.Creator).Value("1").Add().
+		Collect().Label("Subscribe").URL("https://example.com/subscribe").Header("X-Key", "{{KEY}}").
+		Input(Input("email").Type("string").Position(types.ArgPosition(1))).
+		Add().
+		Query(warptest.RegistryContract, "getStake").Label("Check stake").Add().
+		Link("https://example.com").Label("Docs").Add().
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(warp.Actions) != 5 {
+		t.Fatalf("Actions = %d, expected 5", len(warp.Actions))
+	}
+
+	stake := warp.Actions[0].(types.WarpContractAction)
+	if *stake.Func != "stake" || stake.Args[0] != "biguint:1" || stake.GasLimit != 5000000 || stake.Type != types.ContractActionType {
+		t.Errorf("contract action = %+v", stake)
+	}
+	amount := stake.Inputs[0]
+	if amount.Modifier == nil || *amount.Modifier != "scale:18" || amount.Required == nil || !*amount.Required || amount.Source != types.FieldSource {
+		t.Errorf("input = %+v, expected a required input scaled by 18 decimals", amount)
+	}
+
+	collect := warp.Actions[2].(types.WarpCollectAction)
+	if collect.Destination.Method != types.POST || collect.Destination.Headers["X-Key"] != "{{KEY}}" {
+		t.Errorf("collect action = %+v", collect)
+	}
+}
+
+func TestFluentActionErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func(b *WarpBuilder) error
+		expected string
+	}{
+		{"missing label", func(b *WarpBuilder) error {
+			_, err := b.Contract(warptest.RegistryContract).Func("stake").GasLimit(1).Build()
+			return err
+		}, "label is required"},
+		{"long label", func(b *WarpBuilder) error {
+			_, err := b.Link("https://example.com").Label(strings.Repeat("l", 26)).Build()
+			return err
+		}, "longer than 25"},
+		{"wallet as contract", func(b *WarpBuilder) error {
+			_, err := b.Contract(warptest.Creator).Label("Stake").Func("stake").GasLimit(1).Build()
+			return err
+		}, "invalid contract address"},
+		{"missing func", func(b *WarpBuilder) error {
+			_, err := b.Contract(warptest.RegistryContract).Label("Stake").GasLimit(1).Build()
+			return err
+		}, "func is required"},
+		{"missing gas limit", func(b *WarpBuilder) error {
+			_, err := b.Contract(warptest.RegistryContract).Label("Stake").Func("stake").Build()
+			return err
+		}, "gas limit is required"},
+		{"untyped arg", func(b *WarpBuilder) error {
+			_, err := b.Contract(warptest.RegistryContract).Label("Stake").Func("stake").GasLimit(1).Arg("1").Build()
+			return err
+		}, "has no type"},
+		{"invalid hex", func(b *WarpBuilder) error {
+			_, err := b.Contract(warptest.RegistryContract).Label("Stake").Func("stake").GasLimit(1).Arg("hex:zz").Build()
+			return err
+		}, "not valid hex"},
+		{"missing receiver", func(b *WarpBuilder) error {
+			_, err := b.Transfer().Label("Send").Value("1").Build()
+			return err
+		}, "receiver is required"},
+		{"transfer without amount", func(b *WarpBuilder) error {
+			_, err := b.Transfer().Label("Send").To(warptest.Creator).TokenTransfer("USDC-c76f1f", 0, "").Build()
+			return err
+		}, "needs an amount"},
+		{"relative collect URL", func(b *WarpBuilder) error {
+			_, err := b.Collect().Label("Send").URL("/subscribe").Build()
+			return err
+		}, "invalid destination URL"},
+		{"shared position", func(b *WarpBuilder) error {
+			_, err := b.Transfer().Label("Send").
+				Input(Input("to").Type("address").Position(types.ReceiverPosition)).
+				Input(Input("other").Type("address").Position(types.ReceiverPosition)).
+				Build()
+			return err
+		}, "share the position receiver"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.build(NewWarpBuilder(types.WarpConfig{}))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Build() error = %v, expected %q", err, tt.expected)
+			}
+		})
+	}
+
+	// Transfers to a receiver input and placeholder addresses are valid
+	if _, err := NewWarpBuilder(types.WarpConfig{}).Transfer().Label("Send").
+		Input(Input("to").Type("address").Position(types.ReceiverPosition)).Build(); err != nil {
+		t.Errorf("Build() error = %v, expected a receiver input to be accepted", err)
+	}
+	if _, err := NewWarpBuilder(types.WarpConfig{}).Contract("{{CONTRACT}}").Label("Stake").Func("stake").GasLimit(1).Build(); err != nil {
+		t.Errorf("Build() error = %v, expected a placeholder address to be accepted", err)
+	}
+
+	// Errors of added actions are returned when building the warp
+	_, err := NewWarpBuilder(types.WarpConfig{}).SetName("stake").SetTitle("Stake").
+		Contract(warptest.RegistryContract).Label("Stake").Add().
+		Build()
+	if err == nil || !strings.Contains(err.Error(), "func is required") {
+		t.Errorf("Build() error = %v, expected the contract action error", err)
+	}
+}
+
+func TestInputBuilder(t *testing.T) {
+	input, err := Input("amount").Type("biguint").Position(types.ArgPosition(1)).Scale(18).Required().Min(1).Max("{{MAX}}").Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if input.Name != "amount" || input.Position != "arg:1" || *input.Modifier != "scale:18" || input.Min != 1 || input.Max != "{{MAX}}" {
+		t.Errorf("Build() = %+v", input)
+	}
+
+	if _, err := Input("choice").Type("option:uint64").Position(types.ArgPosition(2)).Source(types.QuerySource).Build(); err != nil {
+		t.Errorf("Build() error = %v, expected a wrapped type from the query", err)
+	}
+
+	invalid := map[string]*InputBuilder{
+		"missing name":     Input("").Type("biguint").Position(types.ValuePosition),
+		"unknown type":     Input("amount").Type("money").Position(types.ValuePosition),
+		"invalid arg":      Input("amount").Type("biguint").Position(types.ArgPosition(0)),
+		"unknown source":   Input("amount").Type("biguint").Position(types.ValuePosition).Source("cookie"),
+		"inverted range":   Input("amount").Type("biguint").Position(types.ValuePosition).Min(10).Max(1),
+		"scaled string":    Input("memo").Type("string").Position(types.ArgPosition(1)).Scale(2),
+		"negative scale":   Input("amount").Type("biguint").Position(types.ValuePosition).Scale(-1),
+		"missing position": Input("amount").Type("biguint"),
+	}
+	for name, builder := range invalid {
+		if _, err := builder.Build(); err == nil {
+			t.Errorf("%s: Build() error = nil, expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/cache"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/protocol"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/validator"
+)
+
+// BrandBuilder provides functionality for building, inscribing and fetching brands
+type BrandBuilder struct {
+	config       types.WarpConfig
+	cache        *cache.WarpCache
+	pendingBrand types.Brand
+}
+
+// NewBrandBuilder creates a new BrandBuilder instance
+func NewBrandBuilder(config types.WarpConfig) *BrandBuilder {
+	return NewBrandBuilderWithCache(config, cache.NewWarpCache())
+}
+
+// NewBrandBuilderWithCache creates a new BrandBuilder instance caching fetched brands in an existing
+// cache, e.g. the cache of the component that owns it
+func NewBrandBuilderWithCache(config types.WarpConfig, warpCache *cache.WarpCache) *BrandBuilder {
+	return &BrandBuilder{
+		config: config,
+		cache:  warpCache,
+		pendingBrand: types.Brand{
+			Protocol: utils.GetLatestProtocolIdentifier(types.BrandProtocol),
+		},
+	}
+}
+
+// CreateInscriptionTransaction creates an unsigned transaction inscribing a brand on the blockchain.
+// The transaction is sent from the user address to itself with the brand as data.
+func (b *BrandBuilder) CreateInscriptionTransaction(brand *types.Brand) (*types.Transaction, error) {
+	if err := validator.NewWarpValidator(b.config).ValidateBrand(brand); err != nil {
+		return nil, err
+	}
+
+	// The meta is derived from the inscription transaction, it is not part of the inscription
+	inscribed := *brand
+	inscribed.Meta = nil
+
+	data, err := json.Marshal(inscribed)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := createInscriptionTransaction(b.config, data)
+	if err != nil {
+		return nil, fmt.Errorf("BrandBuilder: %w", err)
+	}
+
+	return tx, nil
+}
+
+// CreateFromRaw creates a brand from a raw JSON string.
+// Brands of older protocol versions are upgraded to the current model, see protocol.Upgrade.
+func (b *BrandBuilder) CreateFromRaw(encoded string, validate bool) (*types.Brand, error) {
+	upgraded, err := protocol.Upgrade(types.BrandProtocol, []byte(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("BrandBuilder: %w", err)
+	}
+
+	var brand types.Brand
+	if err := json.Unmarshal(upgraded, &brand); err != nil {
+		return nil, fmt.Errorf("BrandBuilder: invalid brand: %w", err)
+	}
+
+	if validate {
+		if err := validator.NewWarpValidator(b.config).ValidateBrand(&brand); err != nil {
+			return nil, err
+		}
+	}
+
+	return &brand, nil
+}
+
+// CreateFromTransaction creates a brand from a transaction
+func (b *BrandBuilder) CreateFromTransaction(txData string, sender string, timestamp int64, txHash string, validate bool) (*types.Brand, error) {
+	brand, err := b.CreateFromRaw(txData, validate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add metadata
+	brand.Meta = &types.BrandMeta{
+		Hash:      txHash,
+		Creator:   sender,
+		CreatedAt: utils.FormatTimeISO8601(time.Unix(timestamp, 0)),
+	}
+
+	return brand, nil
+}
+
+// CreateFromTransactionHash creates a brand from the hash of its inscription transaction
+func (b *BrandBuilder) CreateFromTransactionHash(hash string, cacheConfig *types.WarpCacheConfig) (brand *types.Brand, err error) {
+	done := observability.Track(b.config.Observer, observability.OpBrandFetch, slog.String("hash", hash))
+	defer func() { done(err) }()
+
+	// Check cache
+	if cacheConfig != nil {
+		cachedBrand := b.cache.Get(cache.CacheKey.Brand(hash))
+		observability.RecordCacheLookup(b.config.Observer, "brand", cachedBrand != nil)
+		if cachedBrand != nil {
+			return copyBrand(cachedBrand.(*types.Brand)), nil
+		}
+	}
+
+	tx, err := fetchInscription(b.config, hash)
+	if err != nil {
+		return nil, fmt.Errorf("BrandBuilder: %w", err)
+	}
+
+	brand, err = b.CreateFromTransaction(string(tx.Data), tx.Sender, tx.Timestamp, hash, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the brand if caching is enabled
+	if cacheConfig != nil && cacheConfig.TTL > 0 {
+		b.cache.Set(cache.CacheKey.Brand(hash), copyBrand(brand), cacheConfig.TTL)
+	}
+
+	return brand, nil
+}
+
+// SetName sets the name of the pending brand
+func (b *BrandBuilder) SetName(name string) *BrandBuilder {
+	b.pendingBrand.Name = name
+	return b
+}
+
+// SetDescription sets the description of the pending brand
+func (b *BrandBuilder) SetDescription(description string) *BrandBuilder {
+	b.pendingBrand.Description = description
+	return b
+}
+
+// SetLogo sets the logo URL of the pending brand
+func (b *BrandBuilder) SetLogo(logo string) *BrandBuilder {
+	b.pendingBrand.Logo = logo
+	return b
+}
+
+// SetWebURL sets the website URL of the pending brand
+func (b *BrandBuilder) SetWebURL(web string) *BrandBuilder {
+	b.pendingBrand.URLs = &types.BrandURLs{Web: &web}
+	return b
+}
+
+// SetColors sets the primary and secondary colors of the pending brand
+func (b *BrandBuilder) SetColors(primary string, secondary string) *BrandBuilder {
+	b.pendingBrand.Colors = &types.BrandColors{Primary: &primary, Secondary: &secondary}
+	return b
+}
+
+// SetCTA sets the call-to-action of the pending brand
+func (b *BrandBuilder) SetCTA(cta types.BrandCTA) *BrandBuilder {
+	b.pendingBrand.CTA = &cta
+	return b
+}
+
+// Build builds the pending brand
+func (b *BrandBuilder) Build() (*types.Brand, error) {
+	if b.pendingBrand.Name == "" {
+		return nil, errors.New("BrandBuilder: name is required")
+	}
+
+	if err := validator.NewWarpValidator(b.config).ValidateBrand(&b.pendingBrand); err != nil {
+		return nil, err
+	}
+
+	return copyBrand(&b.pendingBrand), nil
+}
+
+// copyBrand returns a deep copy of a brand, so that callers cannot modify the pending or cached brands
+func copyBrand(brand *types.Brand) *types.Brand {
+	copied := *brand
+	if brand.URLs != nil {
+		copied.URLs = &types.BrandURLs{Web: copyString(brand.URLs.Web)}
+	}
+	if brand.Colors != nil {
+		copied.Colors = &types.BrandColors{Primary: copyString(brand.Colors.Primary), Secondary: copyString(brand.Colors.Secondary)}
+	}
+	if brand.CTA != nil {
+		cta := *brand.CTA
+		copied.CTA = &cta
+	}
+	if brand.Meta != nil {
+		meta := *brand.Meta
+		copied.Meta = &meta
+	}
+	return &copied
+}
+
+// copyString returns a copy of an optional string
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	copied := *s
+	return &copied
+}
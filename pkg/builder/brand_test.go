@@ -0,0 +1,111 @@
+package builder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestBrandCreateInscriptionTransaction(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	brand := warptest.FixtureBrand()
+	brand.Meta = &types.BrandMeta{Hash: server.BrandHash}
+
+	tx, err := NewBrandBuilder(server.Config()).CreateInscriptionTransaction(&brand)
+	if err != nil {
+		t.Fatalf("CreateInscriptionTransaction() error = %v", err)
+	}
+	if tx.Sender != warptest.Creator || tx.Receiver != warptest.Creator || tx.Value != "0" {
+		t.Errorf("Sender, Receiver, Value = %s, %s, %s, expected a self transfer", tx.Sender, tx.Receiver, tx.Value)
+	}
+
+	var inscribed types.Brand
+	if err := json.Unmarshal(tx.Data, &inscribed); err != nil {
+		t.Fatalf("Data is not a brand: %v", err)
+	}
+	if inscribed.Name != brand.Name || inscribed.Meta != nil {
+		t.Errorf("Data = %s, expected the brand without meta", tx.Data)
+	}
+
+	brand.Protocol = "warp-0.0.2"
+	if _, err := NewBrandBuilder(server.Config()).CreateInscriptionTransaction(&brand); err == nil {
+		t.Error("CreateInscriptionTransaction() error = nil, expected an invalid protocol")
+	}
+}
+
+func TestBrandCreateFromTransactionHash(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	brandBuilder := NewBrandBuilder(server.Config())
+	cacheConfig := &types.WarpCacheConfig{TTL: 60}
+
+	brand, err := brandBuilder.CreateFromTransactionHash(server.BrandHash, cacheConfig)
+	if err != nil {
+		t.Fatalf("CreateFromTransactionHash() error = %v", err)
+	}
+	if brand.Name != warptest.FixtureBrand().Name {
+		t.Errorf("Name = %s, expected %s", brand.Name, warptest.FixtureBrand().Name)
+	}
+	if brand.Meta == nil || brand.Meta.Hash != server.BrandHash || brand.Meta.Creator != warptest.Creator {
+		t.Errorf("Meta = %v, expected hash %s by %s", brand.Meta, server.BrandHash, warptest.Creator)
+	}
+
+	// The cached brand is served even once the chain API is gone, as copies of the cached brand
+	server.Close()
+	name := brand.Name
+	brand.Name = "changed"
+	brand.Meta.Creator = "changed"
+	cached, err := brandBuilder.CreateFromTransactionHash(server.BrandHash, cacheConfig)
+	if err != nil {
+		t.Fatalf("CreateFromTransactionHash() cached error = %v", err)
+	}
+	if cached.Name != name || cached.Meta == nil || cached.Meta.Creator != warptest.Creator {
+		t.Errorf("CreateFromTransactionHash() = %+v, expected the cached brand unchanged", cached)
+	}
+	if again, _ := brandBuilder.CreateFromTransactionHash(server.BrandHash, cacheConfig); again == cached || again.Meta == cached.Meta {
+		t.Error("CreateFromTransactionHash() returned the cached brand itself, expected a copy")
+	}
+}
+
+func TestBrandBuild(t *testing.T) {
+	brand, err := NewBrandBuilder(types.WarpConfig{}).
+		SetName("My Brand").
+		SetDescription("My brand description").
+		SetLogo("https://example.com/logo.png").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if brand.Protocol != "brand-0.0.2" {
+		t.Errorf("Protocol = %s, expected the latest brand protocol", brand.Protocol)
+	}
+
+	// The built brand is a copy, later changes to either do not affect the other
+	brandBuilder := NewBrandBuilder(types.WarpConfig{}).
+		SetName("My Brand").
+		SetDescription("My brand description").
+		SetLogo("https://example.com/logo.png").
+		SetWebURL("https://example.com")
+	first, err := brandBuilder.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	*first.URLs.Web = "https://phishing.example"
+	first.Name = "Changed"
+	second, err := brandBuilder.SetDescription("Another description").Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if second.Name != "My Brand" || *second.URLs.Web != "https://example.com" || first.Description != "My brand description" {
+		t.Errorf("Build() = %v and %v, expected independent copies", first, second)
+	}
+
+	if _, err := NewBrandBuilder(types.WarpConfig{}).SetName("My Brand").Build(); err == nil {
+		t.Error("Build() error = nil, expected missing description and logo")
+	}
+}
@@ -3,11 +3,13 @@ package builder
 import (
 	"encoding/json"
 	"errors"
-	"io/ioutil"
-	"net/http"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/cache"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/protocol"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/validator"
@@ -18,13 +20,20 @@ type WarpBuilder struct {
 	config     types.WarpConfig
 	cache      *cache.WarpCache
 	pendingWarp types.Warp
+	actionErrors []error // errors of the actions added with the fluent action builders
 }
 
 // NewWarpBuilder creates a new WarpBuilder instance
 func NewWarpBuilder(config types.WarpConfig) *WarpBuilder {
+	return NewWarpBuilderWithCache(config, cache.NewWarpCache())
+}
+
+// NewWarpBuilderWithCache creates a new WarpBuilder instance caching fetched warps in an existing
+// cache, e.g. the cache of the component that owns it
+func NewWarpBuilderWithCache(config types.WarpConfig, warpCache *cache.WarpCache) *WarpBuilder {
 	return &WarpBuilder{
 		config: config,
-		cache:  cache.NewWarpCache(),
+		cache:  warpCache,
 		pendingWarp: types.Warp{
 			Protocol:    utils.GetLatestProtocolIdentifier(types.WarpProtocol),
 			Name:        "",
@@ -53,9 +62,16 @@ func (b *WarpBuilder) CreateInscriptionTransaction(warp *types.Warp) (string, er
 }
 
 // CreateFromRaw creates a warp from a raw JSON string
+// The warp is returned as inscribed, its {{VAR}} placeholders are left for utils.ApplyVars.
+// Warps of older protocol versions are upgraded to the current model, see protocol.Upgrade.
 func (b *WarpBuilder) CreateFromRaw(encoded string, validate bool) (*types.Warp, error) {
+	upgraded, err := protocol.Upgrade(types.WarpProtocol, []byte(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("WarpBuilder: %w", err)
+	}
+
 	var warp types.Warp
-	if err := json.Unmarshal([]byte(encoded), &warp); err != nil {
+	if err := json.Unmarshal(upgraded, &warp); err != nil {
 		return nil, err
 	}
 
@@ -66,7 +82,7 @@ func (b *WarpBuilder) CreateFromRaw(encoded string, validate bool) (*types.Warp,
 		}
 	}
 
-	return utils.PrepareVars(&warp, b.config), nil
+	return &warp, nil
 }
 
 // CreateFromTransaction creates a warp from a transaction
@@ -87,51 +103,25 @@ func (b *WarpBuilder) CreateFromTransaction(txData string, sender string, timest
 }
 
 // CreateFromTransactionHash creates a warp from a transaction hash
-func (b *WarpBuilder) CreateFromTransactionHash(hash string, cacheConfig *types.WarpCacheConfig) (*types.Warp, error) {
+func (b *WarpBuilder) CreateFromTransactionHash(hash string, cacheConfig *types.WarpCacheConfig) (warp *types.Warp, err error) {
+	done := observability.Track(b.config.Observer, observability.OpWarpFetch, slog.String("hash", hash))
+	defer func() { done(err) }()
+
 	// Check cache
 	if cacheConfig != nil {
 		cachedWarp := b.cache.Get(cache.CacheKey.Warp(hash))
+		observability.RecordCacheLookup(b.config.Observer, "warp", cachedWarp != nil)
 		if cachedWarp != nil {
 			return cachedWarp.(*types.Warp), nil
 		}
 	}
 
-	// In a real implementation, this would call the chain API to get the transaction
-	// For now, we simulate it with a placeholder
-	// For real implementation, use the ChainAPIURL from the config
-	
-	// Simplified for the example - in a real implementation, you would call the MultiversX API
-	chainAPIURL := b.config.ChainAPIURL
-	if chainAPIURL == "" {
-		chainAPIURL = "https://api.multiversx.com"
-	}
-
-	resp, err := http.Get(chainAPIURL + "/transactions/" + hash)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("failed to get transaction")
-	}
-
-	body, err := ioutil.ReadAll(resp.Body)
+	tx, err := fetchInscription(b.config, hash)
 	if err != nil {
-		return nil, err
-	}
-
-	var txResponse struct {
-		Data     string `json:"data"`
-		Sender   string `json:"sender"`
-		Timestamp int64  `json:"timestamp"`
-	}
-
-	if err := json.Unmarshal(body, &txResponse); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("WarpBuilder: %w", err)
 	}
 
-	warp, err := b.CreateFromTransaction(txResponse.Data, txResponse.Sender, txResponse.Timestamp, hash, false)
+	warp, err = b.CreateFromTransaction(string(tx.Data), tx.Sender, tx.Timestamp, hash, false)
 	if err != nil {
 		return nil, err
 	}
@@ -144,6 +134,16 @@ func (b *WarpBuilder) CreateFromTransactionHash(hash string, cacheConfig *types.
 	return warp, nil
 }
 
+// MatchesInscription reports whether a warp has the same content as the warp inscribed in a transaction,
+// ignoring the meta. Both warps are compared untemplated, so their vars must match too, see utils.SameContent.
+func (b *WarpBuilder) MatchesInscription(warp *types.Warp, hash string, cacheConfig *types.WarpCacheConfig) (bool, error) {
+	inscribed, err := b.CreateFromTransactionHash(hash, cacheConfig)
+	if err != nil {
+		return false, err
+	}
+	return utils.SameContent(warp, inscribed)
+}
+
 // SetName sets the name of the pending warp
 func (b *WarpBuilder) SetName(name string) *WarpBuilder {
 	b.pendingWarp.Name = name
@@ -182,6 +182,10 @@ func (b *WarpBuilder) AddAction(action types.WarpAction) *WarpBuilder {
 
 // Build builds the pending warp
 func (b *WarpBuilder) Build() (*types.Warp, error) {
+	if len(b.actionErrors) > 0 {
+		return nil, errors.Join(b.actionErrors...)
+	}
+
 	// Validate required fields
 	if b.pendingWarp.Protocol == "" {
 		return nil, errors.New("WarpBuilder: protocol is required")
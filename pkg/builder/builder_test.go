@@ -0,0 +1,134 @@
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/protocol"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestCreateFromRawProtocol(t *testing.T) {
+	warpBuilder := NewWarpBuilder(types.WarpConfig{})
+	warp := warptest.FixtureWarp()
+
+	warp.Protocol = "warp-1.0.0"
+	encoded, _ := json.Marshal(warp)
+	_, err := warpBuilder.CreateFromRaw(string(encoded), false)
+	var unsupported *protocol.UnsupportedVersionError
+	if !errors.As(err, &unsupported) {
+		t.Errorf("CreateFromRaw() error = %v, expected an UnsupportedVersionError", err)
+	}
+
+	warp.Protocol = "warp-0.0.1"
+	encoded, _ = json.Marshal(warp)
+	decoded, err := warpBuilder.CreateFromRaw(string(encoded), true)
+	if err != nil || decoded.Title != warp.Title {
+		t.Errorf("CreateFromRaw() = %v, %v, expected an older version to be decoded", decoded, err)
+	}
+
+	// Malformed protocols are decoded, and reported by validation
+	warp.Protocol = "warp-1"
+	encoded, _ = json.Marshal(warp)
+	if decoded, err := warpBuilder.CreateFromRaw(string(encoded), false); err != nil || decoded.Protocol != "warp-1" {
+		t.Errorf("CreateFromRaw() = %v, %v, expected a malformed protocol to be decoded", decoded, err)
+	}
+	if _, err := warpBuilder.CreateFromRaw(string(encoded), true); err == nil || !strings.Contains(err.Error(), "/protocol") {
+		t.Errorf("CreateFromRaw() error = %v, expected a /protocol validation error", err)
+	}
+}
+
+func TestCreateFromRawOlderInscription(t *testing.T) {
+	// A warp-0.0.1 inscription as stored on chain, without meta
+	inscription := `{
+		"protocol": "warp-0.0.1",
+		"name": "stake",
+		"title": "Stake EGLD",
+		"description": "Stake your EGLD",
+		"preview": "https://example.com/preview.png",
+		"actions": [{
+			"type": "contract",
+			"label": "Stake",
+			"address": "erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3",
+			"func": "stake",
+			"args": [],
+			"gasLimit": 5000000
+		}]
+	}`
+
+	warp, err := NewWarpBuilder(types.WarpConfig{}).CreateFromRaw(inscription, true)
+	if err != nil {
+		t.Fatalf("CreateFromRaw() error = %v", err)
+	}
+	if warp.Protocol != "warp-0.0.1" || warp.Title != "Stake EGLD" || len(warp.Actions) != 1 {
+		t.Errorf("CreateFromRaw() = %+v, expected the older warp as inscribed", warp)
+	}
+	action, ok := warp.Actions[0].(types.WarpContractAction)
+	if !ok || action.Func == nil || *action.Func != "stake" || action.GasLimit != 5000000 {
+		t.Errorf("Actions[0] = %+v, expected the stake contract action", warp.Actions[0])
+	}
+}
+
+func TestMatchesInscription(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	warp := warptest.FixtureWarp()
+	warp.Vars = map[types.WarpVarPlaceholder]string{"GAS": "5000000"}
+	warp.Title = "Stake {{GAS}}"
+	hash := server.AddWarp(warp, warptest.RegistryContract)
+
+	warpBuilder := NewWarpBuilder(server.Config())
+	matches, err := warpBuilder.MatchesInscription(&warp, hash, nil)
+	if err != nil || !matches {
+		t.Errorf("MatchesInscription() = %v, %v, expected the inscribed warp to match", matches, err)
+	}
+
+	// Vars are compared untemplated
+	other := warp
+	other.Vars = map[types.WarpVarPlaceholder]string{"GAS": "6000000"}
+	if matches, err := warpBuilder.MatchesInscription(&other, hash, nil); err != nil || matches {
+		t.Errorf("MatchesInscription() = %v, %v, expected other var values not to match", matches, err)
+	}
+
+	warp.Name = "other-warp"
+	if matches, err := warpBuilder.MatchesInscription(&warp, hash, nil); err != nil || matches {
+		t.Errorf("MatchesInscription() = %v, %v, expected another warp not to match", matches, err)
+	}
+}
+
+func TestContentHashIndependentOfConfig(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	warp := warptest.FixtureWarp()
+	warp.Vars = map[types.WarpVarPlaceholder]string{"USER": "user:wallet"}
+	warp.Title = "Stake for {{USER}}"
+	hash := server.AddWarp(warp, warptest.RegistryContract)
+
+	hashes := make(map[string]bool)
+	for _, userAddress := range []string{warptest.Creator, warptest.RegistryContract} {
+		config := server.Config()
+		config.UserAddress = userAddress
+		fetched, err := NewWarpBuilder(config).CreateFromTransactionHash(hash, nil)
+		if err != nil {
+			t.Fatalf("CreateFromTransactionHash() error = %v", err)
+		}
+		if fetched.Title != warp.Title {
+			t.Errorf("CreateFromTransactionHash() title = %q, expected the placeholder to be kept", fetched.Title)
+		}
+
+		contentHash, err := utils.ContentHash(fetched)
+		if err != nil {
+			t.Fatalf("ContentHash() error = %v", err)
+		}
+		hashes[contentHash] = true
+	}
+	if len(hashes) != 1 {
+		t.Errorf("ContentHash() = %v, expected the same hash for every user", hashes)
+	}
+}
@@ -0,0 +1,174 @@
+package builder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/abi"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// numberInputTypes lists the input types that can be scaled by a number of decimals
+var numberInputTypes = map[types.BaseWarpActionInputType]bool{
+	types.Uint8InputType:   true,
+	types.Uint16InputType:  true,
+	types.Uint32InputType:  true,
+	types.Uint64InputType:  true,
+	types.BigUintInputType: true,
+}
+
+// InputBuilder builds an action input, e.g. Input("amount").Type("biguint").Position(types.ValuePosition).Scale(18)
+type InputBuilder struct {
+	input types.WarpActionInput
+	scale *int
+}
+
+// Input creates a builder for an input filled in by the user from a field
+func Input(name string) *InputBuilder {
+	return &InputBuilder{
+		input: types.WarpActionInput{
+			Name:   name,
+			Source: types.FieldSource,
+		},
+	}
+}
+
+// Type sets the type of the input, e.g. "biguint" or "option:uint64"
+func (b *InputBuilder) Type(inputType types.WarpActionInputType) *InputBuilder {
+	b.input.Type = inputType
+	return b
+}
+
+// Position sets where the input is used, e.g. types.ValuePosition or types.ArgPosition(1)
+func (b *InputBuilder) Position(position types.WarpActionInputPosition) *InputBuilder {
+	b.input.Position = position
+	return b
+}
+
+// Source sets where the input value comes from, types.FieldSource by default
+func (b *InputBuilder) Source(source types.WarpActionInputSource) *InputBuilder {
+	b.input.Source = source
+	return b
+}
+
+// As sets the name the input value is given to a query or collect destination
+func (b *InputBuilder) As(as string) *InputBuilder {
+	b.input.As = &as
+	return b
+}
+
+// Description sets the description of the input
+func (b *InputBuilder) Description(description string) *InputBuilder {
+	b.input.Description = &description
+	return b
+}
+
+// Required marks the input as required
+func (b *InputBuilder) Required() *InputBuilder {
+	required := true
+	b.input.Required = &required
+	return b
+}
+
+// Min sets the minimum value of the input, a number or a {{VAR}} placeholder
+func (b *InputBuilder) Min(min interface{}) *InputBuilder {
+	b.input.Min = min
+	return b
+}
+
+// Max sets the maximum value of the input, a number or a {{VAR}} placeholder
+func (b *InputBuilder) Max(max interface{}) *InputBuilder {
+	b.input.Max = max
+	return b
+}
+
+// Pattern sets the regular expression the input value must match, and its description
+func (b *InputBuilder) Pattern(pattern string, description string) *InputBuilder {
+	b.input.Pattern = &pattern
+	b.input.PatternDescription = &description
+	return b
+}
+
+// Options sets the values the user can choose from
+func (b *InputBuilder) Options(options ...string) *InputBuilder {
+	b.input.Options = options
+	return b
+}
+
+// Scale scales the input value by a number of decimals, e.g. 18 to enter EGLD amounts
+func (b *InputBuilder) Scale(decimals int) *InputBuilder {
+	b.scale = &decimals
+	return b
+}
+
+// Build validates and returns the input
+func (b *InputBuilder) Build() (types.WarpActionInput, error) {
+	input := b.input
+	if input.Name == "" {
+		return types.WarpActionInput{}, fmt.Errorf("WarpBuilder: input name is required")
+	}
+	if !isWarpType(string(input.Type)) {
+		return types.WarpActionInput{}, fmt.Errorf("WarpBuilder: input %s: invalid type %q", input.Name, input.Type)
+	}
+	if !isInputPosition(input.Position) {
+		return types.WarpActionInput{}, fmt.Errorf("WarpBuilder: input %s: invalid position %q", input.Name, input.Position)
+	}
+	if input.Source != types.FieldSource && input.Source != types.QuerySource {
+		return types.WarpActionInput{}, fmt.Errorf("WarpBuilder: input %s: invalid source %q", input.Name, input.Source)
+	}
+
+	min, minIsNumber := boundNumber(input.Min)
+	max, maxIsNumber := boundNumber(input.Max)
+	if minIsNumber && maxIsNumber && min > max {
+		return types.WarpActionInput{}, fmt.Errorf("WarpBuilder: input %s: min %v is greater than max %v", input.Name, input.Min, input.Max)
+	}
+
+	if b.scale != nil {
+		if !numberInputTypes[types.BaseWarpActionInputType(input.Type)] {
+			return types.WarpActionInput{}, fmt.Errorf("WarpBuilder: input %s: only number inputs can be scaled", input.Name)
+		}
+		if *b.scale < 0 {
+			return types.WarpActionInput{}, fmt.Errorf("WarpBuilder: input %s: invalid scale %d", input.Name, *b.scale)
+		}
+		modifier := fmt.Sprintf("%s%s%d", types.ScaleModifier, constants.WarpConstants.ArgParamsSeparator, *b.scale)
+		input.Modifier = &modifier
+	}
+
+	return input, nil
+}
+
+// isWarpType returns whether a type is a known warp argument or input type, e.g. "biguint" or "option:uint64"
+func isWarpType(warpType string) bool {
+	return warpType != "" && abi.WarpArgType(warpType+constants.WarpConstants.ArgParamsSeparator) == warpType
+}
+
+// isInputPosition returns whether a position is the receiver, the value, the transfers or an argument
+func isInputPosition(position types.WarpActionInputPosition) bool {
+	switch position {
+	case types.ReceiverPosition, types.ValuePosition, types.TransferPosition:
+		return true
+	}
+	index, found := strings.CutPrefix(string(position), "arg:")
+	if !found {
+		return false
+	}
+	n, err := strconv.Atoi(index)
+	return err == nil && n >= 1 && index == strconv.Itoa(n)
+}
+
+// boundNumber returns the value of a numeric input Min or Max, or false for placeholders
+func boundNumber(bound interface{}) (float64, bool) {
+	switch v := bound.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,93 @@
+package builder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// inscription represents the data of an inscription transaction fetched from the chain API
+type inscription struct {
+	Data      []byte
+	Sender    string
+	Timestamp int64
+}
+
+// chainAPIURL returns the configured chain API URL
+func chainAPIURL(config types.WarpConfig) string {
+	if config.ChainAPIURL != "" {
+		return config.ChainAPIURL
+	}
+	return core.Config.DefaultChainAPIURL(config.Env)
+}
+
+// fetchInscription fetches an inscription transaction by hash from the chain API
+func fetchInscription(config types.WarpConfig, hash string) (*inscription, error) {
+	resp, err := utils.NewHTTPClient(config).Get(chainAPIURL(config) + "/transactions/" + hash)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get transaction %s: %s", hash, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var txResponse struct {
+		Data      string `json:"data"`
+		Sender    string `json:"sender"`
+		Timestamp int64  `json:"timestamp"`
+	}
+	if err := json.Unmarshal(body, &txResponse); err != nil {
+		return nil, err
+	}
+
+	// The chain API returns the transaction data base64 encoded
+	data, err := base64.StdEncoding.DecodeString(txResponse.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction data: %w", err)
+	}
+
+	return &inscription{
+		Data:      data,
+		Sender:    txResponse.Sender,
+		Timestamp: txResponse.Timestamp,
+	}, nil
+}
+
+// createInscriptionTransaction creates an unsigned transaction from the user address to itself carrying the data
+func createInscriptionTransaction(config types.WarpConfig, data []byte) (*types.Transaction, error) {
+	if config.UserAddress == "" {
+		return nil, errors.New("user address not set")
+	}
+	if !address.IsValid(config.UserAddress) {
+		return nil, fmt.Errorf("invalid user address %s", config.UserAddress)
+	}
+
+	txConstants := constants.WarpConstants.Transaction
+
+	return &types.Transaction{
+		Value:    "0",
+		Receiver: config.UserAddress,
+		Sender:   config.UserAddress,
+		GasPrice: txConstants.GasPrice,
+		GasLimit: txConstants.MinGasLimit + txConstants.GasPerDataByte*uint64(len(data)),
+		Data:     data,
+		ChainID:  utils.GetChainID(config.Env),
+		Version:  txConstants.Version,
+	}, nil
+}
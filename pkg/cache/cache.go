@@ -6,23 +6,39 @@ import (
 	"time"
 )
 
-// CacheKey is a type for cache keys
-type CacheKey string
+// Key is a type for cache keys
+type Key string
 
 // Cache key generators
 var CacheKey = struct {
-	Warp         func(hash string) CacheKey
-	RegistryInfo func(key string) CacheKey
-	Brand        func(key string) CacheKey
+	Warp           func(hash string) Key
+	RegistryInfo   func(key string) Key
+	Brand          func(key string) Key
+	RegistryConfig func(contract string) Key
+	Abi            func(ref string) Key
+	Contract       func(address string) Key
+	Verification   func(address string) Key
 }{
-	Warp: func(hash string) CacheKey {
-		return CacheKey(fmt.Sprintf("warp:%s", hash))
+	Warp: func(hash string) Key {
+		return Key(fmt.Sprintf("warp:%s", hash))
 	},
-	RegistryInfo: func(key string) CacheKey {
-		return CacheKey(fmt.Sprintf("registry:%s", key))
+	RegistryInfo: func(key string) Key {
+		return Key(fmt.Sprintf("registry:%s", key))
 	},
-	Brand: func(key string) CacheKey {
-		return CacheKey(fmt.Sprintf("brand:%s", key))
+	Brand: func(key string) Key {
+		return Key(fmt.Sprintf("brand:%s", key))
+	},
+	RegistryConfig: func(contract string) Key {
+		return Key(fmt.Sprintf("registry-config:%s", contract))
+	},
+	Abi: func(ref string) Key {
+		return Key(fmt.Sprintf("abi:%s", ref))
+	},
+	Contract: func(address string) Key {
+		return Key(fmt.Sprintf("contract:%s", address))
+	},
+	Verification: func(address string) Key {
+		return Key(fmt.Sprintf("contract-verification:%s", address))
 	},
 }
 
@@ -34,14 +50,14 @@ type cacheItem struct {
 
 // WarpCache is a simple in-memory cache for warps and related data
 type WarpCache struct {
-	items map[CacheKey]cacheItem
+	items map[Key]cacheItem
 	mutex sync.RWMutex
 }
 
 // NewWarpCache creates a new WarpCache
 func NewWarpCache() *WarpCache {
 	cache := &WarpCache{
-		items: make(map[CacheKey]cacheItem),
+		items: make(map[Key]cacheItem),
 	}
 
 	// Start the cleanup routine
@@ -51,7 +67,7 @@ func NewWarpCache() *WarpCache {
 }
 
 // Get retrieves a value from the cache
-func (c *WarpCache) Get(key CacheKey) interface{} {
+func (c *WarpCache) Get(key Key) interface{} {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
@@ -70,7 +86,7 @@ func (c *WarpCache) Get(key CacheKey) interface{} {
 }
 
 // Set adds a value to the cache with the specified TTL in seconds
-func (c *WarpCache) Set(key CacheKey, value interface{}, ttlSeconds int) {
+func (c *WarpCache) Set(key Key, value interface{}, ttlSeconds int) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -84,7 +100,7 @@ func (c *WarpCache) Set(key CacheKey, value interface{}, ttlSeconds int) {
 }
 
 // Delete removes a value from the cache
-func (c *WarpCache) Delete(key CacheKey) {
+func (c *WarpCache) Delete(key Key) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -96,7 +112,7 @@ func (c *WarpCache) Clear() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	c.items = make(map[CacheKey]cacheItem)
+	c.items = make(map[Key]cacheItem)
 }
 
 // cleanup periodically removes expired items from the cache
@@ -16,6 +16,12 @@ var WarpConstants = struct {
 		DisplayName string
 		Decimals    int
 	}
+	Transaction            struct {
+		GasPrice       uint64
+		MinGasLimit    uint64
+		GasPerDataByte uint64
+		Version        uint32
+	}
 }{
 	HTTPProtocolPrefix:     "http",
 	IdentifierParamName:    "warp",
@@ -38,4 +44,15 @@ var WarpConstants = struct {
 		DisplayName: "eGold",
 		Decimals:    18,
 	},
+	Transaction: struct {
+		GasPrice       uint64
+		MinGasLimit    uint64
+		GasPerDataByte uint64
+		Version        uint32
+	}{
+		GasPrice:       1000000000,
+		MinGasLimit:    50000,
+		GasPerDataByte: 1500,
+		Version:        1,
+	},
 } 
\ No newline at end of file
@@ -0,0 +1,194 @@
+// Package contract loads information about the smart contracts targeted by warp actions
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/cache"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// WarpContractLoader fetches the owner and verification of smart contracts from the chain API
+type WarpContractLoader struct {
+	config types.WarpConfig
+	cache  *cache.WarpCache
+}
+
+// NewWarpContractLoader creates a new WarpContractLoader instance
+func NewWarpContractLoader(config types.WarpConfig) *WarpContractLoader {
+	return NewWarpContractLoaderWithCache(config, cache.NewWarpCache())
+}
+
+// NewWarpContractLoaderWithCache creates a new WarpContractLoader instance caching contracts in an
+// existing cache, e.g. the cache of the component that owns it
+func NewWarpContractLoaderWithCache(config types.WarpConfig, warpCache *cache.WarpCache) *WarpContractLoader {
+	return &WarpContractLoader{
+		config: config,
+		cache:  warpCache,
+	}
+}
+
+// GetContract fetches the owner, code hash and verified status of a contract.
+// The returned contract is a copy, changing it does not affect the cache.
+func (l *WarpContractLoader) GetContract(contractAddress string, cacheConfig *types.WarpCacheConfig) (contract *types.WarpContract, err error) {
+	done := observability.Track(l.config.Observer, observability.OpContractFetch, slog.String("address", contractAddress))
+	defer func() { done(err) }()
+
+	if !address.IsSmartContract(contractAddress) {
+		return nil, fmt.Errorf("WarpContractLoader: %s is not a smart contract address", contractAddress)
+	}
+
+	// Check cache
+	if cacheConfig != nil {
+		cached := l.cache.Get(cache.CacheKey.Contract(contractAddress))
+		observability.RecordCacheLookup(l.config.Observer, "contract", cached != nil)
+		if cached != nil {
+			return copyContract(cached.(*types.WarpContract)), nil
+		}
+	}
+
+	var account struct {
+		Address      string `json:"address"`
+		OwnerAddress string `json:"ownerAddress"`
+		CodeHash     string `json:"codeHash"`
+		IsVerified   bool   `json:"isVerified"`
+	}
+	found, err := l.get("/accounts/"+contractAddress, &account)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("WarpContractLoader: contract %s not found", contractAddress)
+	}
+
+	contract = &types.WarpContract{
+		Address:  contractAddress,
+		Owner:    account.OwnerAddress,
+		CodeHash: account.CodeHash,
+		Verified: account.IsVerified,
+	}
+
+	// Cache the contract if caching is enabled
+	if cacheConfig != nil && cacheConfig.TTL > 0 {
+		l.cache.Set(cache.CacheKey.Contract(contractAddress), copyContract(contract), cacheConfig.TTL)
+	}
+
+	return contract, nil
+}
+
+// GetVerificationInfo fetches the code hash and the ABI of the verified source code of a contract.
+// It returns nil if the contract is not verified. The returned verification is a copy, changing it
+// does not affect the cache.
+func (l *WarpContractLoader) GetVerificationInfo(contractAddress string, cacheConfig *types.WarpCacheConfig) (verification *types.WarpContractVerification, err error) {
+	done := observability.Track(l.config.Observer, observability.OpContractFetch,
+		slog.String("address", contractAddress), slog.String("kind", "verification"))
+	defer func() { done(err) }()
+
+	// Check cache
+	if cacheConfig != nil {
+		cached := l.cache.Get(cache.CacheKey.Verification(contractAddress))
+		observability.RecordCacheLookup(l.config.Observer, "contract-verification", cached != nil)
+		if cached != nil {
+			return copyVerification(cached.(*types.WarpContractVerification))
+		}
+	}
+
+	var response struct {
+		CodeHash string `json:"codeHash"`
+		Source   struct {
+			Abi *types.AbiContents `json:"abi"`
+		} `json:"source"`
+	}
+	found, err := l.get("/accounts/"+contractAddress+"/verification", &response)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	verification = &types.WarpContractVerification{
+		CodeHash: response.CodeHash,
+		ABI:      response.Source.Abi,
+	}
+
+	// Cache the verification if caching is enabled
+	if cacheConfig != nil && cacheConfig.TTL > 0 {
+		cached, err := copyVerification(verification)
+		if err != nil {
+			return nil, err
+		}
+		l.cache.Set(cache.CacheKey.Verification(contractAddress), cached, cacheConfig.TTL)
+	}
+
+	return verification, nil
+}
+
+// copyContract returns a copy of a contract. The loader does not set the ABI, so the copy shares nothing.
+func copyContract(contract *types.WarpContract) *types.WarpContract {
+	copied := *contract
+	return &copied
+}
+
+// copyVerification returns a deep copy of a contract verification
+func copyVerification(verification *types.WarpContractVerification) (*types.WarpContractVerification, error) {
+	copied := *verification
+	abi, err := copyAbi(verification.ABI)
+	if err != nil {
+		return nil, err
+	}
+	copied.ABI = abi
+	return &copied, nil
+}
+
+// copyAbi returns a deep copy of ABI contents, through their JSON encoding
+func copyAbi(contents *types.AbiContents) (*types.AbiContents, error) {
+	if contents == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return nil, fmt.Errorf("WarpContractLoader: failed to copy ABI: %w", err)
+	}
+	var copied types.AbiContents
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, fmt.Errorf("WarpContractLoader: failed to copy ABI: %w", err)
+	}
+	return &copied, nil
+}
+
+// get decodes the JSON response of a chain API endpoint, returning false if it was not found
+func (l *WarpContractLoader) get(path string, target interface{}) (bool, error) {
+	chainAPIURL := l.config.ChainAPIURL
+	if chainAPIURL == "" {
+		chainAPIURL = core.Config.DefaultChainAPIURL(l.config.Env)
+	}
+
+	resp, err := utils.NewHTTPClient(l.config).Get(chainAPIURL + path)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("WarpContractLoader: failed to get %s: %s", path, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(body, target); err != nil {
+		return false, fmt.Errorf("WarpContractLoader: invalid response for %s: %w", path, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,74 @@
+package contract
+
+import (
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestGetContract(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	loader := NewWarpContractLoader(server.Config())
+	cacheConfig := &types.WarpCacheConfig{TTL: 60}
+
+	contract, err := loader.GetContract(warptest.RegistryContract, cacheConfig)
+	if err != nil {
+		t.Fatalf("GetContract() error = %v", err)
+	}
+	expected := types.WarpContract{Address: warptest.RegistryContract, Owner: warptest.Creator, CodeHash: server.ContractCodeHash, Verified: true}
+	if *contract != expected {
+		t.Errorf("GetContract() = %+v, expected %+v", *contract, expected)
+	}
+
+	// Served from the cache once the server is gone, as copies of the cached contract
+	server.Close()
+	contract.Owner = "changed"
+	cached, err := loader.GetContract(warptest.RegistryContract, cacheConfig)
+	if err != nil || *cached != expected {
+		t.Errorf("GetContract() = %v, %v, expected the cached contract", cached, err)
+	}
+	if again, _ := loader.GetContract(warptest.RegistryContract, cacheConfig); again == cached {
+		t.Error("GetContract() returned the cached contract itself, expected a copy")
+	}
+
+	if _, err := loader.GetContract(warptest.Creator, nil); err == nil {
+		t.Error("GetContract() error = nil, expected a user address to be rejected")
+	}
+}
+
+func TestGetVerificationInfo(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	loader := NewWarpContractLoader(server.Config())
+	cacheConfig := &types.WarpCacheConfig{TTL: 60}
+
+	verification, err := loader.GetVerificationInfo(warptest.RegistryContract, cacheConfig)
+	if err != nil {
+		t.Fatalf("GetVerificationInfo() error = %v", err)
+	}
+	if verification.CodeHash != server.ContractCodeHash {
+		t.Errorf("CodeHash = %s, expected %s", verification.CodeHash, server.ContractCodeHash)
+	}
+	if _, found := verification.ABI.Endpoint("getStake"); !found {
+		t.Errorf("ABI = %+v, expected the fixture ABI", verification.ABI)
+	}
+
+	// Changing a verification does not affect the cached one
+	verification.ABI.Endpoints = nil
+	cached, err := loader.GetVerificationInfo(warptest.RegistryContract, cacheConfig)
+	if err != nil {
+		t.Fatalf("GetVerificationInfo() cached error = %v", err)
+	}
+	if _, found := cached.ABI.Endpoint("getStake"); !found {
+		t.Errorf("ABI = %+v, expected the cached fixture ABI", cached.ABI)
+	}
+
+	unverified := "erd1qqqqqqqqqqqqqpgqp699jngundfqw07d8jzkepucvpzush6k3wvqyc44rx"
+	if verification, err := loader.GetVerificationInfo(unverified, nil); err != nil || verification != nil {
+		t.Errorf("GetVerificationInfo() = %v, %v, expected no verification", verification, err)
+	}
+}
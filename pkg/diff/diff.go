@@ -0,0 +1,286 @@
+// Package diff compares two versions of a warp, e.g. before a creator publishes an upgrade
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// Kind represents how a value changed
+type Kind string
+
+const (
+	Added   Kind = "added"
+	Removed Kind = "removed"
+	Changed Kind = "changed"
+)
+
+// securityFields are the fields whose changes affect what a warp does with the user's assets or data
+var securityFields = map[string]bool{
+	"address":     true,
+	"args":        true,
+	"abi":         true,
+	"destination": true,
+	"func":        true,
+	"max":         true,
+	"min":         true,
+	"next":        true,
+	"position":    true,
+	"receiver":    true,
+	"transfers":   true,
+	"type":        true,
+	"url":         true,
+	"value":       true,
+	"vars":        true,
+}
+
+// Change represents a value added, removed or changed between two warps
+type Change struct {
+	Path     string      `json:"path"` // JSON pointer, in the new warp except for removed values
+	Kind     Kind        `json:"kind"`
+	Old      interface{} `json:"old,omitempty"`
+	New      interface{} `json:"new,omitempty"`
+	Security bool        `json:"security"` // the change affects addresses, values, URLs, funcs or other security-relevant fields
+}
+
+// String returns the change as "~ path: old -> new", "+ path: new" or "- path: old"
+func (c Change) String() string {
+	var line string
+	switch c.Kind {
+	case Added:
+		line = fmt.Sprintf("+ %s: %s", c.Path, render(c.New))
+	case Removed:
+		line = fmt.Sprintf("- %s: %s", c.Path, render(c.Old))
+	default:
+		line = fmt.Sprintf("~ %s: %s -> %s", c.Path, render(c.Old), render(c.New))
+	}
+	if c.Security {
+		line += " [security]"
+	}
+	return line
+}
+
+// Diff represents the changes between two warps. It renders as text with String and as JSON with json.Marshal.
+type Diff struct {
+	Changes []Change `json:"changes"`
+}
+
+// Empty reports whether the warps have the same content
+func (d *Diff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// SecurityChanges returns the security-relevant changes
+func (d *Diff) SecurityChanges() []Change {
+	var changes []Change
+	for _, change := range d.Changes {
+		if change.Security {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// String returns the changes, one per line
+func (d *Diff) String() string {
+	if d.Empty() {
+		return "no changes"
+	}
+	lines := make([]string, len(d.Changes))
+	for i, change := range d.Changes {
+		lines[i] = change.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compare returns the changes from the old to the new version of a warp, ignoring their meta.
+// Both warps are compared in their canonical form, see utils.CanonicalJSON: empty fields are not changes.
+// Actions are matched by position and inputs by name, so a renamed input is removed and added.
+func Compare(before *types.Warp, after *types.Warp) (*Diff, error) {
+	oldDocument, err := document(before)
+	if err != nil {
+		return nil, fmt.Errorf("WarpDiff: %w", err)
+	}
+	newDocument, err := document(after)
+	if err != nil {
+		return nil, fmt.Errorf("WarpDiff: %w", err)
+	}
+
+	d := &Diff{Changes: []Change{}}
+	d.compare("", oldDocument, newDocument)
+	return d, nil
+}
+
+// document decodes the canonical JSON encoding of a warp
+func document(warp *types.Warp) (map[string]interface{}, error) {
+	canonical, err := utils.CanonicalJSON(warp)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(canonical))
+	decoder.UseNumber()
+	var result map[string]interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// compare records the changes between two values at a path
+func (d *Diff) compare(path string, before interface{}, after interface{}) {
+	oldObject, oldIsObject := before.(map[string]interface{})
+	newObject, newIsObject := after.(map[string]interface{})
+	if oldIsObject && newIsObject {
+		d.compareObjects(path, oldObject, newObject)
+		return
+	}
+
+	oldArray, oldIsArray := before.([]interface{})
+	newArray, newIsArray := after.([]interface{})
+	if oldIsArray && newIsArray {
+		if strings.HasSuffix(path, "/inputs") {
+			d.compareInputs(path, oldArray, newArray)
+		} else {
+			d.compareArrays(path, oldArray, newArray)
+		}
+		return
+	}
+
+	if render(before) != render(after) {
+		d.add(path, Changed, before, after)
+	}
+}
+
+// compareObjects records the changes between the fields of two objects, in key order
+func (d *Diff) compareObjects(path string, before map[string]interface{}, after map[string]interface{}) {
+	keys := make([]string, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, found := before[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := path + "/" + escape(key)
+		oldValue, inOld := before[key]
+		newValue, inNew := after[key]
+		switch {
+		case !inOld:
+			d.add(fieldPath, Added, nil, newValue)
+		case !inNew:
+			d.add(fieldPath, Removed, oldValue, nil)
+		default:
+			d.compare(fieldPath, oldValue, newValue)
+		}
+	}
+}
+
+// compareArrays records the changes between two arrays, matching their items by position
+func (d *Diff) compareArrays(path string, before []interface{}, after []interface{}) {
+	for i := 0; i < len(before) || i < len(after); i++ {
+		itemPath := fmt.Sprintf("%s/%d", path, i)
+		switch {
+		case i >= len(before):
+			d.add(itemPath, Added, nil, after[i])
+		case i >= len(after):
+			d.add(itemPath, Removed, before[i], nil)
+		default:
+			d.compare(itemPath, before[i], after[i])
+		}
+	}
+}
+
+// compareInputs records the changes between two lists of inputs, matching them by name
+func (d *Diff) compareInputs(path string, before []interface{}, after []interface{}) {
+	oldIndexes := make(map[string]int, len(before))
+	for i, input := range before {
+		oldIndexes[inputName(input)] = i
+	}
+
+	matched := make(map[int]bool, len(before))
+	for j, input := range after {
+		itemPath := fmt.Sprintf("%s/%d", path, j)
+		i, found := oldIndexes[inputName(input)]
+		if !found || matched[i] {
+			d.add(itemPath, Added, nil, input)
+			continue
+		}
+		matched[i] = true
+		d.compare(itemPath, before[i], input)
+	}
+
+	for i, input := range before {
+		if !matched[i] {
+			d.add(fmt.Sprintf("%s/%d", path, i), Removed, input, nil)
+		}
+	}
+}
+
+// add records a change, flagging it if the path or the added or removed value holds a security-relevant field
+func (d *Diff) add(path string, kind Kind, before interface{}, after interface{}) {
+	security := false
+	for _, token := range strings.Split(path, "/") {
+		security = security || securityFields[token]
+	}
+	if kind != Changed {
+		security = security || hasSecurityField(before) || hasSecurityField(after)
+	}
+
+	d.Changes = append(d.Changes, Change{Path: path, Kind: kind, Old: before, New: after, Security: security})
+}
+
+// hasSecurityField reports whether a value is an object with a security-relevant field, at any depth
+func hasSecurityField(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if securityFields[key] || hasSecurityField(field) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if hasSecurityField(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inputName returns the name of an input
+func inputName(input interface{}) string {
+	if fields, ok := input.(map[string]interface{}); ok {
+		if name, ok := fields["name"].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// escape escapes a JSON pointer token as described in RFC 6901
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// render encodes a value as compact JSON
+func render(value interface{}) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Sprint(value)
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
@@ -0,0 +1,116 @@
+package diff
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestCompare(t *testing.T) {
+	before := warptest.FixtureWarp()
+	stake := before.Actions[0].(types.WarpContractAction)
+	stake.Inputs = []types.WarpActionInput{
+		{Name: "amount", Type: "biguint", Position: types.ValuePosition, Max: float64(10)},
+		{Name: "memo", Type: "string", Position: types.ArgPosition(1)},
+	}
+	before.Actions = []types.WarpAction{stake}
+
+	after := warptest.FixtureWarp()
+	after.Title = "Upgraded Warp"
+	after.Meta = &types.WarpMeta{Hash: "upgrade"}
+	upgraded := stake
+	upgraded.Address = "erd1qqqqqqqqqqqqqpgqattacker"
+	upgraded.Inputs = []types.WarpActionInput{
+		{Name: "amount", Type: "biguint", Position: types.ValuePosition},
+		{Name: "note", Type: "string", Position: types.ArgPosition(1)},
+	}
+	after.Actions = []types.WarpAction{
+		upgraded,
+		types.WarpLinkAction{Type: types.LinkActionType, Label: "Docs", URL: "https://example.com"},
+	}
+
+	d, err := Compare(&before, &after)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	expected := []struct {
+		path     string
+		kind     Kind
+		security bool
+	}{
+		{"/actions/0/address", Changed, true},
+		{"/actions/0/inputs/0/max", Removed, true},
+		{"/actions/0/inputs/1", Added, true},
+		{"/actions/0/inputs/1", Removed, true},
+		{"/actions/1", Added, true},
+		{"/title", Changed, false},
+	}
+	if len(d.Changes) != len(expected) {
+		t.Fatalf("Compare() =\n%s\nexpected %d changes", d, len(expected))
+	}
+	for i, change := range d.Changes {
+		if change.Path != expected[i].path || change.Kind != expected[i].kind || change.Security != expected[i].security {
+			t.Errorf("Changes[%d] = %s, expected %s %s (security %v)", i, change, expected[i].kind, expected[i].path, expected[i].security)
+		}
+	}
+	if len(d.SecurityChanges()) != 5 {
+		t.Errorf("SecurityChanges() = %v, expected 5 changes", d.SecurityChanges())
+	}
+
+	text := d.String()
+	if !strings.Contains(text, `~ /title: "Fixture Warp" -> "Upgraded Warp"`) ||
+		!strings.Contains(text, `~ /actions/0/address: "`+warptest.RegistryContract+`" -> "erd1qqqqqqqqqqqqqpgqattacker" [security]`) {
+		t.Errorf("String() =\n%s", text)
+	}
+
+	encoded, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded Diff
+	if err := json.Unmarshal(encoded, &decoded); err != nil || len(decoded.Changes) != len(d.Changes) || decoded.Changes[5].New != "Upgraded Warp" {
+		t.Errorf("Marshal() = %s, %v", encoded, err)
+	}
+}
+
+func TestCompareActionType(t *testing.T) {
+	before := warptest.FixtureWarp()
+	before.Actions = []types.WarpAction{types.WarpLinkAction{Type: types.LinkActionType, Label: "Open", URL: "https://example.com"}}
+
+	after := warptest.FixtureWarp()
+	after.Actions = []types.WarpAction{types.WarpTransferAction{Type: types.TransferActionType, Label: "Open"}}
+
+	d, err := Compare(&before, &after)
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	for _, change := range d.Changes {
+		if change.Path == "/actions/0/type" {
+			if !change.Security {
+				t.Errorf("Changes = %s, expected the action type change to be a security change", change)
+			}
+			return
+		}
+	}
+	t.Errorf("Compare() =\n%s\nexpected an /actions/0/type change", d)
+}
+
+func TestCompareUnchanged(t *testing.T) {
+	before := warptest.FixtureWarp()
+	after := warptest.FixtureWarp()
+	after.Meta = &types.WarpMeta{Hash: "abc"}
+	after.Vars = map[types.WarpVarPlaceholder]string{}
+
+	d, err := Compare(&before, &after)
+	if err != nil || !d.Empty() || d.String() != "no changes" {
+		t.Errorf("Compare() = %v, %v, expected no changes", d, err)
+	}
+
+	if _, err := Compare(nil, &after); err == nil {
+		t.Error("Compare(nil) error = nil, expected an error")
+	}
+}
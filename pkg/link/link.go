@@ -1,28 +1,64 @@
 package link
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
 	"strings"
 
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/builder"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/cache"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/contract"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/registry"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
 	"github.com/skip2/go-qrcode"
 )
 
+// DefaultMaxUpgradeHops is the maximum number of upgrades followed when DetectOptions.MaxUpgradeHops is not set
+const DefaultMaxUpgradeHops = 10
+
+var (
+	// ErrUpgradeLoop is returned when a warp's upgrade chain points back to a version already visited
+	ErrUpgradeLoop = errors.New("WarpLink: upgrade loop detected")
+
+	// ErrTooManyUpgrades is returned when a warp's upgrade chain is longer than the maximum hop count
+	ErrTooManyUpgrades = errors.New("WarpLink: too many upgrades")
+)
+
+// DetectOptions controls how a detected warp is resolved
+type DetectOptions struct {
+	// FollowUpgrades resolves the warp to the latest version of its registry upgrade chain.
+	// When false, the warp is pinned to the exact version referenced by the URL.
+	FollowUpgrades bool
+
+	// MaxUpgradeHops limits the number of upgrades followed, DefaultMaxUpgradeHops if not set
+	MaxUpgradeHops int
+
+	// IncludeContracts fetches the contracts targeted by the warp's contract and query actions, with
+	// the code hash and ABI of their verified source, into DetectionResult.Contracts, e.g. to badge
+	// actions on verified contracts
+	IncludeContracts bool
+}
+
 // DetectionResult represents the result of a warp detection
 type DetectionResult struct {
-	Match        bool            `json:"match"`
-	URL          string          `json:"url"`
-	Warp         *types.Warp     `json:"warp"`
-	RegistryInfo *types.RegistryInfo `json:"registryInfo"`
-	Brand        *types.Brand    `json:"brand"`
+	Match         bool                `json:"match"`
+	URL           string              `json:"url"`
+	Warp          *types.Warp         `json:"warp"`
+	RegistryInfo  *types.RegistryInfo `json:"registryInfo"`
+	Brand         *types.Brand        `json:"brand"`
+	RequestedHash string              `json:"requestedHash,omitempty"` // hash referenced by the URL
+	ResolvedHash  string              `json:"resolvedHash,omitempty"`  // hash of the returned warp
+
+	// Contracts targeted by the warp's actions, keyed by address, nil for contracts that could not be
+	// fetched. Only set with DetectOptions.IncludeContracts.
+	Contracts map[string]*types.WarpContract `json:"contracts,omitempty"`
 }
 
 // DetectionResultFromHTML represents the result of detecting warps in HTML content
@@ -33,13 +69,20 @@ type DetectionResultFromHTML struct {
 
 // WarpLink provides functionality for generating and detecting warp links
 type WarpLink struct {
-	config types.WarpConfig
+	config    types.WarpConfig
+	builder   *builder.WarpBuilder
+	registry  *registry.WarpRegistry
+	contracts *contract.WarpContractLoader
 }
 
-// NewWarpLink creates a new WarpLink instance
+// NewWarpLink creates a new WarpLink instance. Its components share a single cache.
 func NewWarpLink(config types.WarpConfig) *WarpLink {
+	warpCache := cache.NewWarpCache()
 	return &WarpLink{
-		config: config,
+		config:    config,
+		builder:   builder.NewWarpBuilderWithCache(config, warpCache),
+		registry:  registry.NewWarpRegistryWithCache(config, warpCache),
+		contracts: contract.NewWarpContractLoaderWithCache(config, warpCache),
 	}
 }
 
@@ -103,8 +146,16 @@ func (wl *WarpLink) DetectFromHTML(content string) (*DetectionResultFromHTML, er
 	}, nil
 }
 
-// Detect detects a warp from a URL
+// Detect detects a warp from a URL, pinned to the exact version the URL references
 func (wl *WarpLink) Detect(urlStr string) (*DetectionResult, error) {
+	return wl.DetectWithOptions(urlStr, DetectOptions{})
+}
+
+// DetectWithOptions detects a warp from a URL using the specified resolution options
+func (wl *WarpLink) DetectWithOptions(urlStr string, opts DetectOptions) (result *DetectionResult, err error) {
+	done := observability.Track(wl.config.Observer, observability.OpDetect)
+	defer func() { done(err) }()
+
 	var idResult *struct {
 		Type types.WarpIDType
 		ID   string
@@ -116,95 +167,169 @@ func (wl *WarpLink) Detect(urlStr string) (*DetectionResult, error) {
 		idResult = utils.GetInfoFromPrefixedIdentifier(urlStr)
 	}
 
-	if idResult == nil {
-		return &DetectionResult{
-			Match:        false,
-			URL:          urlStr,
-			Warp:         nil,
-			RegistryInfo: nil,
-			Brand:        nil,
-		}, nil
+	noMatch := &DetectionResult{
+		Match:        false,
+		URL:          urlStr,
+		Warp:         nil,
+		RegistryInfo: nil,
+		Brand:        nil,
 	}
 
-	warpType := idResult.Type
-	id := idResult.ID
-	warpBuilder := builder.NewWarpBuilder(wl.config)
-	warpRegistry := registry.NewWarpRegistry(wl.config)
+	if idResult == nil {
+		return noMatch, nil
+	}
 
-	var warp *types.Warp
-	var registryInfo *types.RegistryInfo
-	var brand *types.Brand
-	var err error
+	var hash string
+	var registryResult *registry.RegistryResult
 
-	if warpType == types.HashIDType {
-		// Get the warp from the transaction hash
-		warp, err = warpBuilder.CreateFromTransactionHash(id, nil)
-		if err != nil {
-			return &DetectionResult{
-				Match:        false,
-				URL:          urlStr,
-				Warp:         nil,
-				RegistryInfo: nil,
-				Brand:        nil,
-			}, err
-		}
+	if idResult.Type == types.HashIDType {
+		hash = idResult.ID
 
-		// Try to get registry info
-		registryResult, err := warpRegistry.GetInfoByHash(id)
-		if err == nil && registryResult != nil {
-			registryInfo = registryResult.RegistryInfo
-			brand = registryResult.Brand
+		// Registry info is optional for warps referenced by hash
+		if hashResult, hashErr := wl.registry.GetInfoByHash(hash); hashErr == nil && hashResult != nil && hashResult.RegistryInfo != nil {
+			registryResult = hashResult
 		}
-	} else if warpType == types.AliasIDType {
+	} else if idResult.Type == types.AliasIDType {
 		// Get the registry info by alias
-		registryResult, err := warpRegistry.GetInfoByAlias(id)
-		if err != nil || registryResult == nil {
-			return &DetectionResult{
-				Match:        false,
-				URL:          urlStr,
-				Warp:         nil,
-				RegistryInfo: nil,
-				Brand:        nil,
-			}, err
+		registryResult, err = wl.registry.GetInfoByAlias(idResult.ID)
+		if err != nil || registryResult == nil || registryResult.RegistryInfo == nil {
+			return noMatch, err
 		}
+		hash = registryResult.RegistryInfo.Hash
+	}
 
+	if hash == "" {
+		return noMatch, nil
+	}
+
+	requestedHash := hash
+	var registryInfo *types.RegistryInfo
+	var brand *types.Brand
+	if registryResult != nil {
 		registryInfo = registryResult.RegistryInfo
 		brand = registryResult.Brand
+	}
 
-		if registryInfo != nil {
-			// Get the warp from the hash in registry info
-			warp, err = warpBuilder.CreateFromTransactionHash(registryInfo.Hash, nil)
-			if err != nil {
-				return &DetectionResult{
-					Match:        false,
-					URL:          urlStr,
-					Warp:         nil,
-					RegistryInfo: nil,
-					Brand:        nil,
-				}, err
-			}
+	if opts.FollowUpgrades && registryResult != nil {
+		resolved, err := wl.followUpgrades(registryResult, opts.MaxUpgradeHops)
+		if err != nil {
+			return noMatch, err
+		}
+
+		// The brand belongs to the resolved version, which may not be registered
+		hash = resolved.hash
+		registryInfo, brand = nil, nil
+		if resolved.registryResult != nil {
+			registryInfo = resolved.registryResult.RegistryInfo
+			brand = resolved.registryResult.Brand
 		}
 	}
 
+	// Get the warp from the resolved transaction hash
+	warp, err := wl.builder.CreateFromTransactionHash(hash, nil)
+	if err != nil {
+		return noMatch, err
+	}
 	if warp == nil {
-		return &DetectionResult{
-			Match:        false,
-			URL:          urlStr,
-			Warp:         nil,
-			RegistryInfo: nil,
-			Brand:        nil,
-		}, nil
+		return noMatch, nil
+	}
+
+	var contracts map[string]*types.WarpContract
+	if opts.IncludeContracts {
+		contracts = wl.loadContracts(warp)
 	}
 
 	return &DetectionResult{
-		Match:        true,
-		URL:          urlStr,
-		Warp:         warp,
-		RegistryInfo: registryInfo,
-		Brand:        brand,
+		Match:         true,
+		URL:           urlStr,
+		Warp:          warp,
+		RegistryInfo:  registryInfo,
+		Brand:         brand,
+		RequestedHash: requestedHash,
+		ResolvedHash:  hash,
+		Contracts:     contracts,
 	}, nil
 }
 
+// loadContracts fetches the contracts targeted by the contract and query actions of a warp, keyed by
+// address, with the ABI of their verified source. Contracts that cannot be fetched are recorded as nil,
+// and verified contracts whose source cannot be fetched are returned without ABI.
+func (wl *WarpLink) loadContracts(warp *types.Warp) map[string]*types.WarpContract {
+	var cacheConfig *types.WarpCacheConfig
+	if wl.config.CacheTTL > 0 {
+		cacheConfig = &types.WarpCacheConfig{TTL: wl.config.CacheTTL}
+	}
+
+	contracts := make(map[string]*types.WarpContract)
+	for _, action := range warp.Actions {
+		var contractAddress string
+		switch a := action.(type) {
+		case types.WarpContractAction:
+			contractAddress = a.Address
+		case types.WarpQueryAction:
+			contractAddress = a.Address
+		}
+		// Skip placeholders and repeated targets
+		if _, seen := contracts[contractAddress]; seen || !address.IsSmartContract(contractAddress) {
+			continue
+		}
+
+		info, err := wl.contracts.GetContract(contractAddress, cacheConfig)
+		if err != nil {
+			contracts[contractAddress] = nil
+			continue
+		}
+		if info.Verified {
+			if verification, err := wl.contracts.GetVerificationInfo(contractAddress, cacheConfig); err == nil && verification != nil {
+				info.CodeHash = verification.CodeHash
+				info.ABI = verification.ABI
+			}
+		}
+		contracts[contractAddress] = info
+	}
+
+	return contracts
+}
+
+// resolvedUpgrade represents the latest version found by following an upgrade chain
+type resolvedUpgrade struct {
+	hash           string
+	registryResult *registry.RegistryResult // nil if the latest version is not registered
+}
+
+// followUpgrades follows the registry upgrade chain from a registered warp to its latest version
+func (wl *WarpLink) followUpgrades(start *registry.RegistryResult, maxHops int) (*resolvedUpgrade, error) {
+	if maxHops <= 0 {
+		maxHops = DefaultMaxUpgradeHops
+	}
+
+	current := &resolvedUpgrade{hash: start.RegistryInfo.Hash, registryResult: start}
+	visited := map[string]bool{current.hash: true}
+
+	for hops := 0; current.registryResult != nil && current.registryResult.RegistryInfo.Upgrade != nil; hops++ {
+		next := *current.registryResult.RegistryInfo.Upgrade
+		if visited[next] {
+			return nil, fmt.Errorf("%w: %s upgrades back to %s", ErrUpgradeLoop, current.hash, next)
+		}
+		if hops >= maxHops {
+			return nil, fmt.Errorf("%w: more than %d upgrades from %s", ErrTooManyUpgrades, maxHops, start.RegistryInfo.Hash)
+		}
+		visited[next] = true
+
+		nextResult, err := wl.registry.GetInfoByHash(next)
+		if err != nil {
+			return nil, err
+		}
+		if nextResult != nil && nextResult.RegistryInfo == nil {
+			nextResult = nil
+		}
+
+		current = &resolvedUpgrade{hash: next, registryResult: nextResult}
+	}
+
+	return current, nil
+}
+
 // Build creates a warp URL for the specified type and ID
 func (wl *WarpLink) Build(idType types.WarpIDType, id string) string {
 	clientURL := wl.config.ClientURL
@@ -0,0 +1,213 @@
+package link
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+// addVersions inscribes and registers versions of the fixture warp, each upgrading to the next one
+func addVersions(server *warptest.Server, titles ...string) []string {
+	hashes := make([]string, len(titles))
+	for i, title := range titles {
+		warp := warptest.FixtureWarp()
+		warp.Title = title
+		hashes[i] = server.AddWarp(warp, warptest.Creator)
+	}
+
+	for i, hash := range hashes {
+		info := types.RegistryInfo{
+			Hash:      hash,
+			Trust:     types.Unverified,
+			Creator:   warptest.Creator,
+			CreatedAt: warptest.FixtureTimestamp,
+		}
+		if i+1 < len(hashes) {
+			info.Upgrade = &hashes[i+1]
+		}
+		server.AddRegistryInfo(info)
+	}
+
+	return hashes
+}
+
+func TestDetectFollowUpgrades(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	hashes := addVersions(server, "v1", "v2", "v3")
+	warpLink := NewWarpLink(server.Config())
+
+	pinned, err := warpLink.Detect("hash:" + hashes[0])
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if pinned.Warp.Title != "v1" || pinned.ResolvedHash != hashes[0] {
+		t.Errorf("Detect() = %s (%s), expected the pinned version v1", pinned.Warp.Title, pinned.ResolvedHash)
+	}
+
+	latest, err := warpLink.DetectWithOptions("hash:"+hashes[0], DetectOptions{FollowUpgrades: true})
+	if err != nil {
+		t.Fatalf("DetectWithOptions() error = %v", err)
+	}
+	if latest.Warp.Title != "v3" {
+		t.Errorf("DetectWithOptions().Warp.Title = %s, expected v3", latest.Warp.Title)
+	}
+	if latest.RequestedHash != hashes[0] || latest.ResolvedHash != hashes[2] {
+		t.Errorf("RequestedHash, ResolvedHash = %s, %s, expected %s, %s", latest.RequestedHash, latest.ResolvedHash, hashes[0], hashes[2])
+	}
+	if latest.RegistryInfo == nil || latest.RegistryInfo.Hash != hashes[2] {
+		t.Errorf("RegistryInfo = %v, expected the latest version's entry", latest.RegistryInfo)
+	}
+
+	_, err = warpLink.DetectWithOptions("hash:"+hashes[0], DetectOptions{FollowUpgrades: true, MaxUpgradeHops: 1})
+	if !errors.Is(err, ErrTooManyUpgrades) {
+		t.Errorf("DetectWithOptions() error = %v, expected ErrTooManyUpgrades", err)
+	}
+}
+
+func TestDetectFollowUpgradesBrand(t *testing.T) {
+	for _, registered := range []bool{true, false} {
+		server := warptest.NewServer()
+
+		// Only the old version is branded
+		warp := warptest.FixtureWarp()
+		warp.Title = "v2"
+		upgrade := server.AddWarp(warp, warptest.Creator)
+		if registered {
+			server.AddRegistryInfo(types.RegistryInfo{Hash: upgrade, Trust: types.Unverified, Creator: warptest.Creator})
+		}
+		alias := warptest.WarpAlias
+		brand := server.BrandHash
+		server.AddRegistryInfo(types.RegistryInfo{Hash: server.WarpHash, Alias: &alias, Trust: types.Verified, Creator: warptest.Creator, Brand: &brand, Upgrade: &upgrade})
+
+		result, err := NewWarpLink(server.Config()).DetectWithOptions("alias:"+warptest.WarpAlias, DetectOptions{FollowUpgrades: true})
+		server.Close()
+		if err != nil {
+			t.Fatalf("DetectWithOptions() error = %v", err)
+		}
+		if result.ResolvedHash != upgrade || result.Warp.Title != "v2" {
+			t.Errorf("DetectWithOptions() = %s (%s), expected the upgrade", result.Warp.Title, result.ResolvedHash)
+		}
+		if result.Brand != nil {
+			t.Errorf("DetectWithOptions().Brand = %v, expected no brand for the unbranded upgrade", result.Brand)
+		}
+		if (result.RegistryInfo != nil) != registered {
+			t.Errorf("DetectWithOptions().RegistryInfo = %v, expected registered = %v", result.RegistryInfo, registered)
+		}
+	}
+}
+
+func TestDetectUpgradeLoop(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	hashes := addVersions(server, "v1", "v2")
+	server.AddRegistryInfo(types.RegistryInfo{
+		Hash:    hashes[1],
+		Trust:   types.Unverified,
+		Creator: warptest.Creator,
+		Upgrade: &hashes[0],
+	})
+
+	_, err := NewWarpLink(server.Config()).DetectWithOptions("hash:"+hashes[0], DetectOptions{FollowUpgrades: true})
+	if !errors.Is(err, ErrUpgradeLoop) {
+		t.Errorf("DetectWithOptions() error = %v, expected ErrUpgradeLoop", err)
+	}
+}
+
+func TestDetectAlias(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	result, err := NewWarpLink(server.Config()).Detect("alias:" + warptest.WarpAlias)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if !result.Match || result.ResolvedHash != server.WarpHash {
+		t.Errorf("Detect() = %v, expected the fixture warp", result)
+	}
+	if result.Brand == nil || result.Brand.Name != warptest.FixtureBrand().Name {
+		t.Errorf("Detect().Brand = %v, expected the fixture brand", result.Brand)
+	}
+}
+
+func TestDetectIncludeContracts(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	wl := NewWarpLink(server.Config())
+	result, err := wl.DetectWithOptions("hash:"+server.WarpHash, DetectOptions{IncludeContracts: true})
+	if err != nil {
+		t.Fatalf("DetectWithOptions() error = %v", err)
+	}
+
+	contract := result.Contracts[warptest.RegistryContract]
+	if contract == nil || !contract.Verified || contract.Owner != warptest.Creator {
+		t.Errorf("Contracts[%s] = %+v, expected the verified registry contract", warptest.RegistryContract, contract)
+	}
+	if contract != nil && (contract.CodeHash != server.ContractCodeHash || contract.ABI == nil) {
+		t.Errorf("Contracts[%s] = %+v, expected the code hash and ABI of the verified source", warptest.RegistryContract, contract)
+	}
+
+	if result, _ := wl.Detect("hash:" + server.WarpHash); result.Contracts != nil {
+		t.Errorf("Detect() contracts = %v, expected none without IncludeContracts", result.Contracts)
+	}
+}
+
+func TestDetectIncludeContractsFailure(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	// The chain API fails for one of the two targeted contracts
+	failing := "erd1qqqqqqqqqqqqqpgqp699jngundfqw07d8jzkepucvpzush6k3wvqyc44rx"
+	target, _ := url.Parse(server.URL)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/accounts/"+failing) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		httputil.NewSingleHostReverseProxy(target).ServeHTTP(w, r)
+	}))
+	defer proxy.Close()
+
+	warp := warptest.FixtureWarp()
+	other := warp.Actions[0].(types.WarpContractAction)
+	other.Address = failing
+	warp.Actions = append(warp.Actions, other)
+	hash := server.AddWarp(warp, warptest.Creator)
+
+	config := server.Config()
+	config.ChainAPIURL = proxy.URL
+	result, err := NewWarpLink(config).DetectWithOptions("hash:"+hash, DetectOptions{IncludeContracts: true})
+	if err != nil {
+		t.Fatalf("DetectWithOptions() error = %v", err)
+	}
+	if !result.Match || result.Contracts[warptest.RegistryContract] == nil {
+		t.Errorf("DetectWithOptions() = %+v, expected the warp and the registry contract", result)
+	}
+	if contract, found := result.Contracts[failing]; !found || contract != nil {
+		t.Errorf("Contracts[%s] = %+v, expected an unknown contract", failing, contract)
+	}
+}
+
+func TestDetectionResultMatchesTypes(t *testing.T) {
+	linkType, typesType := reflect.TypeOf(DetectionResult{}), reflect.TypeOf(types.DetectionResult{})
+	if linkType.NumField() != typesType.NumField() {
+		t.Fatalf("DetectionResult has %d fields, types.DetectionResult %d", linkType.NumField(), typesType.NumField())
+	}
+	for i := 0; i < linkType.NumField(); i++ {
+		linkField, typesField := linkType.Field(i), typesType.Field(i)
+		if linkField.Name != typesField.Name || linkField.Tag != typesField.Tag {
+			t.Errorf("DetectionResult field %s %q, types.DetectionResult has %s %q", linkField.Name, linkField.Tag, typesField.Name, typesField.Tag)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Package observability provides logging, tracing and metrics hooks for the SDK
+package observability
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Operation names reported by the SDK components
+const (
+	OpDetect         = "detect"
+	OpRegistryLookup = "registry.lookup"
+	OpRegistrySearch = "registry.search"
+	OpWarpFetch      = "warp.fetch"
+	OpBrandFetch     = "brand.fetch"
+	OpAbiFetch       = "abi.fetch"
+	OpContractFetch  = "contract.fetch"
+	OpSchemaLoad     = "schema.load"
+	OpHTTPCall       = "http.call"
+)
+
+// Metric names reported by the SDK components
+const (
+	MetricCacheHit  = "cache.hit"
+	MetricCacheMiss = "cache.miss"
+)
+
+// Observer receives logs, spans and metrics emitted by the SDK
+type Observer interface {
+	// Logger returns the structured logger used by the SDK
+	Logger() *slog.Logger
+
+	// StartSpan starts a span for the named operation
+	StartSpan(name string, attrs ...slog.Attr) Span
+
+	// IncCounter increments the named counter by one
+	IncCounter(name string, attrs ...slog.Attr)
+
+	// RecordLatency records a latency sample for the named operation
+	RecordLatency(name string, duration time.Duration, attrs ...slog.Attr)
+}
+
+// Span represents an in-flight operation
+type Span interface {
+	// SetAttributes adds attributes to the span
+	SetAttributes(attrs ...slog.Attr)
+
+	// End ends the span, recording the error if the operation failed
+	End(err error)
+}
+
+// Noop returns an Observer that discards everything
+func Noop() Observer {
+	return noopObserver{}
+}
+
+// OrNoop returns the observer, or a no-op observer if it is nil
+func OrNoop(observer Observer) Observer {
+	if observer == nil {
+		return Noop()
+	}
+	return observer
+}
+
+// NewLogObserver creates an Observer that writes spans to the logger and ignores metrics
+func NewLogObserver(logger *slog.Logger) Observer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &logObserver{logger: logger}
+}
+
+// Track starts a span for the operation and returns a function that ends it,
+// counting the call and recording its latency
+func Track(observer Observer, op string, attrs ...slog.Attr) func(err error) {
+	observer = OrNoop(observer)
+	start := time.Now()
+	span := observer.StartSpan(op, attrs...)
+
+	return func(err error) {
+		duration := time.Since(start)
+		status := slog.String("status", "ok")
+		if err != nil {
+			status = slog.String("status", "error")
+		}
+
+		span.End(err)
+		observer.IncCounter(op, append(attrs, status)...)
+		observer.RecordLatency(op, duration, attrs...)
+	}
+}
+
+// RecordCacheLookup counts a cache hit or miss for the given kind of entry
+func RecordCacheLookup(observer Observer, kind string, hit bool) {
+	observer = OrNoop(observer)
+	if hit {
+		observer.IncCounter(MetricCacheHit, slog.String("kind", kind))
+		return
+	}
+	observer.IncCounter(MetricCacheMiss, slog.String("kind", kind))
+}
+
+// NewTransport wraps an http.RoundTripper so that every request is reported as an OpHTTPCall
+func NewTransport(observer Observer, base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{
+		base:     base,
+		observer: OrNoop(observer),
+	}
+}
+
+// transport reports HTTP calls to an Observer
+type transport struct {
+	base     http.RoundTripper
+	observer Observer
+}
+
+// RoundTrip executes a single HTTP transaction
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	done := Track(t.observer, OpHTTPCall,
+		slog.String("method", req.Method),
+		slog.String("host", req.URL.Host),
+		slog.String("path", req.URL.Path))
+
+	resp, err := t.base.RoundTrip(req)
+	if err == nil && resp.StatusCode >= http.StatusBadRequest {
+		t.observer.Logger().Warn("http call failed",
+			slog.String("method", req.Method),
+			slog.String("path", req.URL.Path),
+			slog.Int("status", resp.StatusCode))
+	}
+	done(err)
+
+	return resp, err
+}
+
+// noopObserver discards everything
+type noopObserver struct{}
+
+func (noopObserver) Logger() *slog.Logger                              { return slog.New(discardHandler{}) }
+func (noopObserver) StartSpan(string, ...slog.Attr) Span               { return noopSpan{} }
+func (noopObserver) IncCounter(string, ...slog.Attr)                   {}
+func (noopObserver) RecordLatency(string, time.Duration, ...slog.Attr) {}
+
+// noopSpan discards everything
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...slog.Attr) {}
+func (noopSpan) End(error)                  {}
+
+// discardHandler is a slog.Handler that drops all records
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// logObserver writes spans to a slog.Logger
+type logObserver struct {
+	logger *slog.Logger
+}
+
+func (o *logObserver) Logger() *slog.Logger {
+	return o.logger
+}
+
+func (o *logObserver) StartSpan(name string, attrs ...slog.Attr) Span {
+	return &logSpan{logger: o.logger, name: name, start: time.Now(), attrs: attrs}
+}
+
+func (o *logObserver) IncCounter(string, ...slog.Attr) {}
+
+func (o *logObserver) RecordLatency(string, time.Duration, ...slog.Attr) {}
+
+// logSpan logs its completion at debug level, or at error level if it failed
+type logSpan struct {
+	logger *slog.Logger
+	name   string
+	start  time.Time
+	attrs  []slog.Attr
+}
+
+func (s *logSpan) SetAttributes(attrs ...slog.Attr) {
+	s.attrs = append(s.attrs, attrs...)
+}
+
+func (s *logSpan) End(err error) {
+	attrs := append([]slog.Attr{
+		slog.String("op", s.name),
+		slog.Duration("duration", time.Since(s.start)),
+	}, s.attrs...)
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		s.logger.LogAttrs(context.Background(), slog.LevelError, "operation failed", attrs...)
+		return
+	}
+	s.logger.LogAttrs(context.Background(), slog.LevelDebug, "operation completed", attrs...)
+}
@@ -0,0 +1,99 @@
+package observability
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type recordingObserver struct {
+	spans     []string
+	counters  map[string]int
+	latencies map[string]int
+}
+
+func newRecordingObserver() *recordingObserver {
+	return &recordingObserver{
+		counters:  make(map[string]int),
+		latencies: make(map[string]int),
+	}
+}
+
+func (o *recordingObserver) Logger() *slog.Logger { return Noop().Logger() }
+
+func (o *recordingObserver) StartSpan(name string, attrs ...slog.Attr) Span {
+	o.spans = append(o.spans, name)
+	return noopSpan{}
+}
+
+func (o *recordingObserver) IncCounter(name string, attrs ...slog.Attr) {
+	o.counters[name]++
+}
+
+func (o *recordingObserver) RecordLatency(name string, duration time.Duration, attrs ...slog.Attr) {
+	o.latencies[name]++
+}
+
+func TestTrack(t *testing.T) {
+	observer := newRecordingObserver()
+
+	done := Track(observer, OpDetect)
+	done(errors.New("boom"))
+
+	if len(observer.spans) != 1 || observer.spans[0] != OpDetect {
+		t.Errorf("Track() spans = %v, expected [%s]", observer.spans, OpDetect)
+	}
+	if observer.counters[OpDetect] != 1 {
+		t.Errorf("Track() counter = %d, expected 1", observer.counters[OpDetect])
+	}
+	if observer.latencies[OpDetect] != 1 {
+		t.Errorf("Track() latency samples = %d, expected 1", observer.latencies[OpDetect])
+	}
+}
+
+func TestRecordCacheLookup(t *testing.T) {
+	observer := newRecordingObserver()
+
+	RecordCacheLookup(observer, "warp", true)
+	RecordCacheLookup(observer, "warp", false)
+	RecordCacheLookup(observer, "warp", false)
+
+	if observer.counters[MetricCacheHit] != 1 {
+		t.Errorf("cache hits = %d, expected 1", observer.counters[MetricCacheHit])
+	}
+	if observer.counters[MetricCacheMiss] != 2 {
+		t.Errorf("cache misses = %d, expected 2", observer.counters[MetricCacheMiss])
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	observer := newRecordingObserver()
+	client := &http.Client{Transport: NewTransport(observer, nil)}
+
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if observer.counters[OpHTTPCall] != 1 {
+		t.Errorf("http calls = %d, expected 1", observer.counters[OpHTTPCall])
+	}
+}
+
+func TestOrNoop(t *testing.T) {
+	if OrNoop(nil) == nil {
+		t.Error("OrNoop(nil) = nil, expected no-op observer")
+	}
+
+	// The no-op observer must be safe to use end to end
+	Track(nil, OpDetect)(nil)
+}
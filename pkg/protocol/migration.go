@@ -0,0 +1,135 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// Document is a decoded JSON document, such as a warp, a brand or an ABI. Numbers are kept as json.Number.
+type Document map[string]interface{}
+
+// Migration upgrades documents of a protocol from one version to a newer one
+type Migration struct {
+	From    Version
+	To      Version
+	Migrate func(document Document) error
+}
+
+// Migrator upgrades documents inscribed with older protocol versions by applying its migrations in order
+type Migrator struct {
+	mutex      sync.RWMutex
+	migrations map[types.ProtocolName][]Migration
+}
+
+// NewMigrator creates a new Migrator instance without migrations
+func NewMigrator() *Migrator {
+	return &Migrator{
+		migrations: make(map[types.ProtocolName][]Migration),
+	}
+}
+
+// DefaultMigrator holds the migrations between the published protocol versions. It is used when decoding
+// warps, brands and ABIs. The SDK registers no migrations yet, documents of older versions are decoded as
+// they are unless the application registers migrations for them.
+var DefaultMigrator = NewMigrator()
+
+// Register adds a migration of a protocol to the default migrator
+func Register(name types.ProtocolName, migration Migration) error {
+	return DefaultMigrator.Register(name, migration)
+}
+
+// Upgrade upgrades a document with the default migrator
+func Upgrade(name types.ProtocolName, data []byte) ([]byte, error) {
+	return DefaultMigrator.Upgrade(name, data)
+}
+
+// Register adds a migration of a protocol. Migrations must upgrade to a newer version, no later than the
+// latest version of the protocol, and may not overlap.
+func (m *Migrator) Register(name types.ProtocolName, migration Migration) error {
+	if migration.Migrate == nil {
+		return fmt.Errorf("migration of %s from %s has no function", name, migration.From)
+	}
+	if migration.From.Compare(migration.To) >= 0 {
+		return fmt.Errorf("migration of %s from %s to %s does not upgrade", name, migration.From, migration.To)
+	}
+	if latest := Latest(name); migration.To.Compare(latest.Version) > 0 {
+		return fmt.Errorf("migration of %s to %s is newer than %s", name, migration.To, latest)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, registered := range m.migrations[name] {
+		if migration.From.Compare(registered.To) < 0 && registered.From.Compare(migration.To) < 0 {
+			return fmt.Errorf("migration of %s from %s to %s overlaps the one from %s to %s",
+				name, migration.From, migration.To, registered.From, registered.To)
+		}
+	}
+
+	migrations := append(m.migrations[name], migration)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].From.Compare(migrations[j].From) < 0
+	})
+	m.migrations[name] = migrations
+
+	return nil
+}
+
+// Upgrade checks the protocol of a JSON document and applies the migrations upgrading from its version,
+// in order. Versions between two migrations share the format of the next one. The protocol of the
+// upgraded document is set to the version of the last migration applied.
+// Documents without a protocol, with a protocol that cannot be parsed, or without a migration to apply,
+// are returned unchanged, their protocol is left to validation.
+// A document of a newer major version is rejected with an *UnsupportedVersionError.
+func (m *Migrator) Upgrade(name types.ProtocolName, data []byte) ([]byte, error) {
+	var header struct {
+		Protocol string `json:"protocol"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil || header.Protocol == "" {
+		return data, nil
+	}
+
+	id, err := CheckCompatibility(name, header.Protocol)
+	var unsupported *UnsupportedVersionError
+	if errors.As(err, &unsupported) {
+		return nil, err
+	}
+	if err != nil {
+		return data, nil
+	}
+
+	m.mutex.RLock()
+	var pending []Migration
+	for _, migration := range m.migrations[name] {
+		if migration.To.Compare(id.Version) > 0 {
+			pending = append(pending, migration)
+		}
+	}
+	m.mutex.RUnlock()
+
+	if len(pending) == 0 {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document Document
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	for _, migration := range pending {
+		if err := migration.Migrate(document); err != nil {
+			return nil, fmt.Errorf("failed to migrate %s from %s to %s: %w", name, migration.From, migration.To, err)
+		}
+		document["protocol"] = Identifier{Name: name, Version: migration.To}.String()
+	}
+
+	return json.Marshal(document)
+}
@@ -0,0 +1,121 @@
+// Package protocol parses protocol identifiers, such as "warp-0.0.2", and upgrades documents
+// inscribed with older protocol versions to the current model
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// latestVersions maps each protocol to the version of the current model
+var latestVersions = map[types.ProtocolName]Version{
+	types.WarpProtocol:  {Major: 0, Minor: 0, Patch: 2},
+	types.BrandProtocol: {Major: 0, Minor: 0, Patch: 2},
+	types.AbiProtocol:   {Major: 0, Minor: 0, Patch: 2},
+}
+
+// Version represents a semantic version of a protocol
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses a "MAJOR.MINOR.PATCH" version
+func ParseVersion(version string) (Version, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("invalid protocol version: %s", version)
+	}
+
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 || part != strconv.Itoa(number) {
+			return Version{}, fmt.Errorf("invalid protocol version: %s", version)
+		}
+		numbers[i] = number
+	}
+
+	return Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// String returns the version as "MAJOR.MINOR.PATCH"
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Compare returns a negative number if v is older than other, a positive number if it is newer, and 0 if they are equal
+func (v Version) Compare(other Version) int {
+	if v.Major != other.Major {
+		return v.Major - other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor - other.Minor
+	}
+	return v.Patch - other.Patch
+}
+
+// Identifier represents a protocol identifier, e.g. "warp-0.0.2"
+type Identifier struct {
+	Name    types.ProtocolName
+	Version Version
+}
+
+// Parse parses a protocol identifier of the form "{name}-{version}"
+func Parse(identifier string) (Identifier, error) {
+	separator := strings.LastIndex(identifier, "-")
+	if separator <= 0 {
+		return Identifier{}, fmt.Errorf("invalid protocol identifier: %s", identifier)
+	}
+
+	version, err := ParseVersion(identifier[separator+1:])
+	if err != nil {
+		return Identifier{}, fmt.Errorf("invalid protocol identifier: %s", identifier)
+	}
+
+	return Identifier{Name: types.ProtocolName(identifier[:separator]), Version: version}, nil
+}
+
+// String returns the identifier as "{name}-{version}"
+func (id Identifier) String() string {
+	return fmt.Sprintf("%s-%s", id.Name, id.Version)
+}
+
+// Latest returns the identifier of the current version of a protocol
+func Latest(name types.ProtocolName) Identifier {
+	return Identifier{Name: name, Version: latestVersions[name]}
+}
+
+// UnsupportedVersionError is returned for documents of a major protocol version newer than the SDK supports
+type UnsupportedVersionError struct {
+	Identifier Identifier // the protocol of the document
+	Latest     Identifier // the latest protocol supported by the SDK
+}
+
+// Error describes the unsupported version
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("protocol %s is not supported, the latest supported version is %s", e.Identifier, e.Latest)
+}
+
+// CheckCompatibility parses a protocol identifier and checks that it can be decoded as the given protocol.
+// Newer minor and patch versions are accepted, newer major versions are rejected with an *UnsupportedVersionError.
+func CheckCompatibility(name types.ProtocolName, identifier string) (Identifier, error) {
+	id, err := Parse(identifier)
+	if err != nil {
+		return Identifier{}, err
+	}
+	if id.Name != name {
+		return Identifier{}, fmt.Errorf("invalid %s protocol: %s", name, identifier)
+	}
+
+	latest := Latest(name)
+	if id.Version.Major > latest.Version.Major {
+		return Identifier{}, &UnsupportedVersionError{Identifier: id, Latest: latest}
+	}
+
+	return id, nil
+}
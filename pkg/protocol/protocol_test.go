@@ -0,0 +1,175 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		identifier string
+		expected   Identifier
+		valid      bool
+	}{
+		{"warp-0.0.2", Identifier{Name: types.WarpProtocol, Version: Version{0, 0, 2}}, true},
+		{"brand-1.12.0", Identifier{Name: types.BrandProtocol, Version: Version{1, 12, 0}}, true},
+		{"warp", Identifier{}, false},
+		{"-0.0.2", Identifier{}, false},
+		{"warp-0.2", Identifier{}, false},
+		{"warp-0.0.x", Identifier{}, false},
+		{"warp-0.01.0", Identifier{}, false},
+		{"warp-0.-1.0", Identifier{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identifier, func(t *testing.T) {
+			id, err := Parse(tt.identifier)
+			if (err == nil) != tt.valid {
+				t.Fatalf("Parse(%s) error = %v, expected valid = %v", tt.identifier, err, tt.valid)
+			}
+			if id != tt.expected {
+				t.Errorf("Parse(%s) = %+v, expected %+v", tt.identifier, id, tt.expected)
+			}
+			if tt.valid && id.String() != tt.identifier {
+				t.Errorf("String() = %s, expected %s", id.String(), tt.identifier)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	if (Version{0, 0, 2}).Compare(Version{0, 0, 10}) >= 0 {
+		t.Error("expected 0.0.2 to be older than 0.0.10")
+	}
+	if (Version{1, 0, 0}).Compare(Version{0, 9, 9}) <= 0 {
+		t.Error("expected 1.0.0 to be newer than 0.9.9")
+	}
+	if (Version{0, 1, 0}).Compare(Version{0, 1, 0}) != 0 {
+		t.Error("expected equal versions to compare to 0")
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	if id, err := CheckCompatibility(types.WarpProtocol, "warp-0.0.1"); err != nil || id.Version != (Version{0, 0, 1}) {
+		t.Errorf("CheckCompatibility(warp-0.0.1) = %+v, %v, expected an older version to be accepted", id, err)
+	}
+	if _, err := CheckCompatibility(types.WarpProtocol, "warp-0.3.0"); err != nil {
+		t.Errorf("CheckCompatibility(warp-0.3.0) error = %v, expected a newer minor version to be accepted", err)
+	}
+	if _, err := CheckCompatibility(types.WarpProtocol, "brand-0.0.2"); err == nil {
+		t.Error("CheckCompatibility(brand-0.0.2) error = nil, expected another protocol to be rejected")
+	}
+
+	_, err := CheckCompatibility(types.WarpProtocol, "warp-1.0.0")
+	var unsupported *UnsupportedVersionError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("CheckCompatibility(warp-1.0.0) error = %v, expected an UnsupportedVersionError", err)
+	}
+	if unsupported.Identifier.Version.Major != 1 || unsupported.Latest != Latest(types.WarpProtocol) {
+		t.Errorf("UnsupportedVersionError = %+v", unsupported)
+	}
+}
+
+func TestMigratorUpgrade(t *testing.T) {
+	migrator := NewMigrator()
+	renameLabel := Migration{
+		From: Version{0, 0, 0},
+		To:   Version{0, 0, 1},
+		Migrate: func(document Document) error {
+			document["title"] = document["label"]
+			delete(document, "label")
+			return nil
+		},
+	}
+	addActions := Migration{
+		From: Version{0, 0, 1},
+		To:   Version{0, 0, 2},
+		Migrate: func(document Document) error {
+			if _, found := document["actions"]; !found {
+				document["actions"] = []interface{}{}
+			}
+			return nil
+		},
+	}
+	if err := migrator.Register(types.WarpProtocol, addActions); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := migrator.Register(types.WarpProtocol, renameLabel); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	upgraded, err := migrator.Upgrade(types.WarpProtocol, []byte(`{"protocol":"warp-0.0.0","label":"Old","gas":18446744073709551615}`))
+	if err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	var warp types.Warp
+	if err := json.Unmarshal(upgraded, &warp); err != nil {
+		t.Fatalf("Upgrade() = %s, not a warp: %v", upgraded, err)
+	}
+	if warp.Protocol != "warp-0.0.2" || warp.Title != "Old" || warp.Actions == nil {
+		t.Errorf("Upgrade() = %s, expected both migrations to apply", upgraded)
+	}
+	if !strings.Contains(string(upgraded), "18446744073709551615") {
+		t.Errorf("Upgrade() = %s, expected numbers to keep their precision", upgraded)
+	}
+
+	// Only the migrations to newer versions apply
+	upgraded, err = migrator.Upgrade(types.WarpProtocol, []byte(`{"protocol":"warp-0.0.1","label":"Kept"}`))
+	if err != nil || !strings.Contains(string(upgraded), `"label":"Kept"`) || !strings.Contains(string(upgraded), `"protocol":"warp-0.0.2"`) {
+		t.Errorf("Upgrade() = %s, %v, expected only the 0.0.1 migration to apply", upgraded, err)
+	}
+
+	current := []byte(`{"protocol":"warp-0.0.2","title":"Current"}`)
+	if upgraded, err := migrator.Upgrade(types.WarpProtocol, current); err != nil || string(upgraded) != string(current) {
+		t.Errorf("Upgrade() = %s, %v, expected the current version to be unchanged", upgraded, err)
+	}
+
+	var unsupported *UnsupportedVersionError
+	if _, err := migrator.Upgrade(types.WarpProtocol, []byte(`{"protocol":"warp-2.0.0"}`)); !errors.As(err, &unsupported) {
+		t.Errorf("Upgrade() error = %v, expected an UnsupportedVersionError", err)
+	}
+
+	// Malformed protocols are left to validation
+	malformed := []byte(`{"protocol":"warp-2","label":"Malformed"}`)
+	if upgraded, err := migrator.Upgrade(types.WarpProtocol, malformed); err != nil || string(upgraded) != string(malformed) {
+		t.Errorf("Upgrade() = %s, %v, expected a malformed protocol to be unchanged", upgraded, err)
+	}
+
+	failing := NewMigrator()
+	failing.Register(types.WarpProtocol, Migration{From: Version{0, 0, 1}, To: Version{0, 0, 2}, Migrate: func(Document) error {
+		return errors.New("boom")
+	}})
+	if _, err := failing.Upgrade(types.WarpProtocol, []byte(`{"protocol":"warp-0.0.1"}`)); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Upgrade() error = %v, expected the migration error", err)
+	}
+}
+
+func TestMigratorRegister(t *testing.T) {
+	migrator := NewMigrator()
+	noop := func(Document) error { return nil }
+
+	invalid := []Migration{
+		{From: Version{0, 0, 1}, To: Version{0, 0, 2}},
+		{From: Version{0, 0, 2}, To: Version{0, 0, 1}, Migrate: noop},
+		{From: Version{0, 0, 2}, To: Version{1, 0, 0}, Migrate: noop},
+	}
+	for _, migration := range invalid {
+		if err := migrator.Register(types.WarpProtocol, migration); err == nil {
+			t.Errorf("Register(%s to %s) error = nil, expected an invalid migration", migration.From, migration.To)
+		}
+	}
+
+	if err := migrator.Register(types.WarpProtocol, Migration{From: Version{0, 0, 0}, To: Version{0, 0, 2}, Migrate: noop}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := migrator.Register(types.WarpProtocol, Migration{From: Version{0, 0, 1}, To: Version{0, 0, 2}, Migrate: noop}); err == nil {
+		t.Error("Register() error = nil, expected overlapping migrations to be rejected")
+	}
+	if err := migrator.Register(types.BrandProtocol, Migration{From: Version{0, 0, 1}, To: Version{0, 0, 2}, Migrate: noop}); err != nil {
+		t.Errorf("Register() error = %v, expected migrations of other protocols to be independent", err)
+	}
+}
@@ -0,0 +1,348 @@
+// Package recorder provides an http.RoundTripper that records SDK traffic to a
+// cassette file and replays it offline for deterministic tests
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// Mode controls whether the recorder hits the network or replays the cassette
+type Mode int
+
+const (
+	// ModeReplay only serves responses from the cassette and fails on unknown requests
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the network and records it to the cassette
+	ModeRecord
+	// ModeReplayOrRecord replays the cassette if it exists, and records it otherwise
+	ModeReplayOrRecord
+)
+
+// Redacted replaces secret values in recorded interactions
+const Redacted = "REDACTED"
+
+// ErrNoInteraction is returned in replay mode when no recorded interaction matches a request
+var ErrNoInteraction = errors.New("recorder: no recorded interaction matches the request")
+
+// defaultRedactedParams are query parameters that are always redacted
+var defaultRedactedParams = []string{"apiKey", "api_key", "token"}
+
+// defaultRedactedHeaders are headers that are always redacted
+var defaultRedactedHeaders = []string{"Authorization", "Api-Key", "X-Api-Key", "Cookie"}
+
+// RecordedRequest represents a recorded HTTP request
+type RecordedRequest struct {
+	Method  string              `json:"method"`
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers,omitempty"`
+	Body    string              `json:"body,omitempty"`
+}
+
+// RecordedResponse represents a recorded HTTP response
+type RecordedResponse struct {
+	StatusCode int                 `json:"statusCode"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body"`
+}
+
+// Interaction represents a recorded request/response pair
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette represents the recorded interactions stored in a file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Option customizes a Recorder
+type Option func(r *Recorder)
+
+// WithTransport sets the transport used to reach the network when recording
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithSecrets redacts the given values wherever they appear in recorded interactions
+func WithSecrets(secrets ...string) Option {
+	return func(r *Recorder) {
+		for _, secret := range secrets {
+			if secret != "" {
+				r.secrets = append(r.secrets, secret)
+			}
+		}
+	}
+}
+
+// WithConfigSecrets redacts the secrets of the configuration, such as the index API key
+func WithConfigSecrets(config types.WarpConfig) Option {
+	return WithSecrets(config.IndexAPIKey)
+}
+
+// WithRedactedHeaders redacts the values of the given headers
+func WithRedactedHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		r.headers = append(r.headers, headers...)
+	}
+}
+
+// WithRedactedParams redacts the values of the given query parameters
+func WithRedactedParams(params ...string) Option {
+	return func(r *Recorder) {
+		r.params = append(r.params, params...)
+	}
+}
+
+// Recorder is an http.RoundTripper that records and replays interactions
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	secrets   []string
+	headers   []string
+	params    []string
+
+	mutex    sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// New creates a Recorder backed by the cassette file at the specified path
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	r := &Recorder{
+		path:      path,
+		mode:      mode,
+		transport: http.DefaultTransport,
+		headers:   append([]string{}, defaultRedactedHeaders...),
+		params:    append([]string{}, defaultRedactedParams...),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeReplayOrRecord {
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		} else {
+			r.mode = ModeRecord
+		}
+	}
+
+	if r.mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("recorder: failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("recorder: invalid cassette: %w", err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+
+	return r, nil
+}
+
+// Mode returns the effective mode of the recorder
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// RoundTrip records or replays a single HTTP transaction
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := r.recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeReplay {
+		interaction, err := r.find(recorded)
+		if err != nil {
+			return nil, err
+		}
+		return toResponse(interaction.Response, req), nil
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	r.mutex.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.redactHeaders(resp.Header),
+			Body:       r.redact(string(body)),
+		},
+	})
+	r.mutex.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode == ModeReplay {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(r.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	return ioutil.WriteFile(r.path, data, 0644)
+}
+
+// find returns the first unused interaction matching the request, or the last matching one if all were used
+func (r *Recorder) find(req RecordedRequest) (*Interaction, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var fallback *Interaction
+	for i := range r.cassette.Interactions {
+		interaction := &r.cassette.Interactions[i]
+		if !matches(interaction.Request, req) {
+			continue
+		}
+		if !r.used[i] {
+			r.used[i] = true
+			return interaction, nil
+		}
+		fallback = interaction
+	}
+
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+// matches checks if two requests have the same method, URL and body
+func matches(a RecordedRequest, b RecordedRequest) bool {
+	return a.Method == b.Method && a.URL == b.URL && a.Body == b.Body
+}
+
+// recordRequest converts a request to its redacted recorded form, preserving its body
+func (r *Recorder) recordRequest(req *http.Request) (RecordedRequest, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return RecordedRequest{}, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	return RecordedRequest{
+		Method:  req.Method,
+		URL:     r.redactURL(req.URL),
+		Headers: r.redactHeaders(req.Header),
+		Body:    r.redact(string(body)),
+	}, nil
+}
+
+// redactURL returns the URL with redacted query parameters and secrets
+func (r *Recorder) redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+	for _, param := range r.params {
+		if query.Has(param) {
+			query.Set(param, Redacted)
+		}
+	}
+	redacted.RawQuery = query.Encode()
+
+	return r.redact(redacted.String())
+}
+
+// redactHeaders returns a copy of the headers with redacted values and secrets
+func (r *Recorder) redactHeaders(headers http.Header) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	result := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		redacted := make([]string, len(values))
+		for i, value := range values {
+			redacted[i] = r.redact(value)
+		}
+		result[name] = redacted
+	}
+
+	for _, name := range r.headers {
+		name = http.CanonicalHeaderKey(name)
+		if _, ok := result[name]; ok {
+			result[name] = []string{Redacted}
+		}
+	}
+
+	return result
+}
+
+// redact replaces every secret value in the string
+func (r *Recorder) redact(value string) string {
+	for _, secret := range r.secrets {
+		value = strings.ReplaceAll(value, secret, Redacted)
+		if escaped := url.QueryEscape(secret); escaped != secret {
+			value = strings.ReplaceAll(value, escaped, Redacted)
+		}
+	}
+	return value
+}
+
+// toResponse converts a recorded response to an http.Response for the request
+func toResponse(recorded RecordedResponse, req *http.Request) *http.Response {
+	header := http.Header{}
+	for name, values := range recorded.Headers {
+		header[name] = values
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
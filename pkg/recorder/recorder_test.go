@@ -0,0 +1,69 @@
+package recorder_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/recorder"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/registry"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := warptest.NewServer()
+	cassette := filepath.Join(t.TempDir(), "search.json")
+
+	config := server.Config()
+	config.IndexAPIKey = "super-secret-key"
+
+	// Record the traffic against the stand-in
+	rec, err := recorder.New(cassette, recorder.ModeRecord, recorder.WithConfigSecrets(config))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	config.HTTPTransport = rec
+
+	recorded, err := registry.NewWarpRegistry(config).Search("fixture")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.Contains(string(data), config.IndexAPIKey) {
+		t.Error("cassette contains the index API key")
+	}
+
+	// Replay the traffic with the stand-in stopped
+	rec, err = recorder.New(cassette, recorder.ModeReplayOrRecord, recorder.WithConfigSecrets(config))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if rec.Mode() != recorder.ModeReplay {
+		t.Fatalf("Mode() = %v, expected ModeReplay", rec.Mode())
+	}
+	config.HTTPTransport = rec
+
+	replayed, err := registry.NewWarpRegistry(config).Search("fixture")
+	if err != nil {
+		t.Fatalf("Search() replay error = %v", err)
+	}
+	if len(replayed.Hits) != len(recorded.Hits) || replayed.Hits[0].Hash != recorded.Hits[0].Hash {
+		t.Errorf("Search() replay = %v, expected %v", replayed.Hits, recorded.Hits)
+	}
+
+	// Unknown requests fail in replay mode
+	_, err = registry.NewWarpRegistry(config).Search("other")
+	if !errors.Is(err, recorder.ErrNoInteraction) {
+		t.Errorf("Search() unknown error = %v, expected ErrNoInteraction", err)
+	}
+}
@@ -0,0 +1,221 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/builder"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// DefaultListSize is the page size used when ListOptions.Size is not set
+const DefaultListSize = 25
+
+// ListOptions controls the pagination of creator listings.
+// Pages are taken over the creator's transaction history.
+type ListOptions struct {
+	From int `json:"from"`
+	Size int `json:"size"`
+}
+
+// CreatorWarp represents a warp inscribed by a creator, with its registry entry if it was registered
+type CreatorWarp struct {
+	Warp         *types.Warp         `json:"warp"`
+	RegistryInfo *types.RegistryInfo `json:"registryInfo"`
+}
+
+// CreatorListing represents the warps, aliases and brands published by an account
+type CreatorListing struct {
+	Creator string        `json:"creator"`
+	Warps   []CreatorWarp `json:"warps"`
+	Brands  []types.Brand `json:"brands"`
+	Aliases []string      `json:"aliases"`
+
+	// PublishedBrands lists all brands the creator published in the registry, leaving out brands
+	// whose inscription cannot be fetched
+	PublishedBrands []types.Brand `json:"publishedBrands"`
+
+	From    int  `json:"from"`
+	Size    int  `json:"size"`
+	HasMore bool `json:"hasMore"`
+}
+
+// accountTransaction represents a transaction in the chain API account history
+type accountTransaction struct {
+	TxHash    string `json:"txHash"`
+	Sender    string `json:"sender"`
+	Receiver  string `json:"receiver"`
+	Data      string `json:"data"`
+	Timestamp int64  `json:"timestamp"`
+	Status    string `json:"status"`
+}
+
+// ListByCreator lists the warps and brands inscribed by an account, combining its transaction
+// history with the registry entries and brands it published. Warps and brands are paginated,
+// aliases and published brands cover the whole registry.
+func (r *WarpRegistry) ListByCreator(creator string, opts ListOptions) (*CreatorListing, error) {
+	pubKey, err := address.Decode(creator)
+	if err != nil {
+		return nil, fmt.Errorf("WarpRegistry: invalid creator %s: %w", creator, err)
+	}
+	if opts.From < 0 {
+		return nil, errors.New("WarpRegistry: from must not be negative")
+	}
+	if opts.Size <= 0 {
+		opts.Size = DefaultListSize
+	}
+
+	registryInfos, err := r.getUserWarps(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	brandHashes, err := r.getUserBrands(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one more transaction than the page size to know whether another page follows
+	txs, err := r.fetchInscriptions(creator, ListOptions{From: opts.From, Size: opts.Size + 1})
+	if err != nil {
+		return nil, err
+	}
+	hasMore := len(txs) > opts.Size
+	if hasMore {
+		txs = txs[:opts.Size]
+	}
+
+	listing := &CreatorListing{
+		Creator: creator,
+		Warps:   []CreatorWarp{},
+		Brands:  []types.Brand{},
+		Aliases: []string{},
+
+		PublishedBrands: []types.Brand{},
+
+		From:    opts.From,
+		Size:    opts.Size,
+		HasMore: hasMore,
+	}
+
+	for _, hash := range brandHashes {
+		if brand, err := r.brands.CreateFromTransactionHash(hash, r.brandCacheConfig()); err == nil {
+			listing.PublishedBrands = append(listing.PublishedBrands, *brand)
+		}
+	}
+
+	registryByHash := make(map[string]*types.RegistryInfo, len(registryInfos))
+	for _, info := range registryInfos {
+		registryByHash[info.Hash] = info
+		if info.Alias != nil {
+			listing.Aliases = append(listing.Aliases, *info.Alias)
+		}
+	}
+
+	warpBuilder := builder.NewWarpBuilderWithCache(r.config, r.cache)
+	for _, tx := range txs {
+		data, err := base64.StdEncoding.DecodeString(tx.Data)
+		if err != nil || len(data) == 0 {
+			continue
+		}
+
+		switch inscriptionProtocol(data) {
+		case types.WarpProtocol:
+			warp, err := warpBuilder.CreateFromTransaction(string(data), tx.Sender, tx.Timestamp, tx.TxHash, false)
+			if err != nil {
+				continue
+			}
+			listing.Warps = append(listing.Warps, CreatorWarp{
+				Warp:         warp,
+				RegistryInfo: registryByHash[tx.TxHash],
+			})
+		case types.BrandProtocol:
+			brand, err := r.brands.CreateFromTransaction(string(data), tx.Sender, tx.Timestamp, tx.TxHash, false)
+			if err != nil {
+				continue
+			}
+			listing.Brands = append(listing.Brands, *brand)
+		}
+	}
+
+	return listing, nil
+}
+
+// getUserWarps returns the registry entries created by an account
+func (r *WarpRegistry) getUserWarps(pubKey []byte) ([]*types.RegistryInfo, error) {
+	return r.queryInfos("getUserWarps", pubKey)
+}
+
+// getUserBrands returns the hashes of the brands published by an account
+func (r *WarpRegistry) getUserBrands(pubKey []byte) ([]string, error) {
+	returnData, err := r.queryContract("getUserBrands", pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(returnData))
+	for _, hash := range returnData {
+		hashes = append(hashes, hex.EncodeToString(hash))
+	}
+
+	return hashes, nil
+}
+
+// fetchInscriptions fetches a page of the transactions an account sent to itself, which carry its inscriptions
+func (r *WarpRegistry) fetchInscriptions(creator string, opts ListOptions) ([]accountTransaction, error) {
+	query := url.Values{}
+	query.Set("sender", creator)
+	query.Set("receiver", creator)
+	query.Set("status", "success")
+	query.Set("from", strconv.Itoa(opts.From))
+	query.Set("size", strconv.Itoa(opts.Size))
+
+	apiURL := fmt.Sprintf("%s/accounts/%s/transactions?%s", r.chainAPIURL(), creator, query.Encode())
+	resp, err := utils.NewHTTPClient(r.config).Get(apiURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("WarpRegistry: failed to get transactions of %s: %s", creator, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []accountTransaction
+	if err := json.Unmarshal(body, &txs); err != nil {
+		return nil, err
+	}
+
+	return txs, nil
+}
+
+// inscriptionProtocol returns the protocol of an inscription from its protocol identifier, or "" if it isn't one
+func inscriptionProtocol(data []byte) types.ProtocolName {
+	var header struct {
+		Protocol string `json:"protocol"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return ""
+	}
+
+	for _, protocol := range []types.ProtocolName{types.WarpProtocol, types.BrandProtocol, types.AbiProtocol} {
+		if strings.HasPrefix(header.Protocol, string(protocol)+"-") {
+			return protocol
+		}
+	}
+	return ""
+}
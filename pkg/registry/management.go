@@ -0,0 +1,182 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/cache"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// ErrUnauthorized is matched by errors returned when the user address may not manage a warp
+var ErrUnauthorized = errors.New("WarpRegistry: unauthorized")
+
+// AuthorizationError is returned when the user address is not the creator of the warp it tries to manage
+type AuthorizationError struct {
+	Operation string
+	Hash      string
+	User      string
+	Creator   string
+}
+
+// Error returns the error message
+func (e *AuthorizationError) Error() string {
+	return fmt.Sprintf("WarpRegistry: %s of warp %s requires its creator %s, user is %s", e.Operation, e.Hash, e.Creator, e.User)
+}
+
+// Unwrap allows errors.Is(err, ErrUnauthorized)
+func (e *AuthorizationError) Unwrap() error {
+	return ErrUnauthorized
+}
+
+// PublishUpgradeRequest represents a request to publish a new version of a registered warp
+type PublishUpgradeRequest struct {
+	Hash        string `json:"hash"`        // hash of the registered warp
+	UpgradeHash string `json:"upgradeHash"` // hash of the inscribed new version
+}
+
+// UnregisterRequest represents a request to remove a warp from the registry
+type UnregisterRequest struct {
+	Hash string `json:"hash"`
+}
+
+// TransferOwnershipRequest represents a request to transfer a registered warp and its alias to another account
+type TransferOwnershipRequest struct {
+	Hash     string `json:"hash"`
+	NewOwner string `json:"newOwner"`
+}
+
+// GetConfig reads the registry configuration from the contract's getConfig view.
+// The returned configuration is a copy, changing it does not affect the cache.
+func (r *WarpRegistry) GetConfig() (*RegistryConfig, error) {
+	cacheKey := cache.CacheKey.RegistryConfig(r.contractAddress())
+	if cached := r.cache.Get(cacheKey); cached != nil {
+		return cached.(*RegistryConfig).copy(), nil
+	}
+
+	returnData, err := r.queryContract("getConfig")
+	if err != nil {
+		return nil, err
+	}
+	if len(returnData) < 1 {
+		return nil, errors.New("WarpRegistry: invalid registry configuration")
+	}
+
+	config := &RegistryConfig{
+		UnitPrice: new(big.Int).SetBytes(returnData[0]),
+		Admins:    make([]string, 0, len(returnData)-1),
+	}
+	for _, admin := range returnData[1:] {
+		adminAddress, err := address.Encode(admin)
+		if err != nil {
+			return nil, fmt.Errorf("WarpRegistry: invalid admin: %w", err)
+		}
+		config.Admins = append(config.Admins, adminAddress)
+	}
+
+	if r.config.CacheTTL > 0 {
+		r.cache.Set(cacheKey, config.copy(), r.config.CacheTTL)
+	}
+
+	return config, nil
+}
+
+// copy returns a deep copy of the configuration
+func (c *RegistryConfig) copy() *RegistryConfig {
+	copied := &RegistryConfig{Admins: append([]string{}, c.Admins...)}
+	if c.UnitPrice != nil {
+		copied.UnitPrice = new(big.Int).Set(c.UnitPrice)
+	}
+	return copied
+}
+
+// PublishUpgrade creates a transaction pointing a registered warp to its new version
+func (r *WarpRegistry) PublishUpgrade(req PublishUpgradeRequest) (*types.Transaction, error) {
+	hashBytes, err := decodeHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	upgradeHashBytes, err := decodeHash(req.UpgradeHash)
+	if err != nil {
+		return nil, err
+	}
+	if req.Hash == req.UpgradeHash {
+		return nil, errors.New("WarpRegistry: a warp cannot upgrade to itself")
+	}
+
+	if err := r.authorize("upgrade", req.Hash); err != nil {
+		return nil, err
+	}
+
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.createTransaction("publishUpgrade", config.UnitPrice, hashBytes, upgradeHashBytes)
+}
+
+// Unregister creates a transaction removing a warp and its alias from the registry
+func (r *WarpRegistry) Unregister(req UnregisterRequest) (*types.Transaction, error) {
+	hashBytes, err := decodeHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.authorize("unregister", req.Hash); err != nil {
+		return nil, err
+	}
+
+	return r.createTransaction("unregisterWarp", nil, hashBytes)
+}
+
+// TransferOwnership creates a transaction transferring a registered warp and its alias to another account
+func (r *WarpRegistry) TransferOwnership(req TransferOwnershipRequest) (*types.Transaction, error) {
+	hashBytes, err := decodeHash(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+	newOwner, err := address.Decode(req.NewOwner)
+	if err != nil {
+		return nil, fmt.Errorf("WarpRegistry: invalid new owner %s: %w", req.NewOwner, err)
+	}
+
+	if err := r.authorize("ownership transfer", req.Hash); err != nil {
+		return nil, err
+	}
+
+	return r.createTransaction("transferOwnership", nil, hashBytes, newOwner)
+}
+
+// authorize checks that the user address is the creator of the registered warp.
+// The registry is queried directly, a cached entry could predate an ownership transfer.
+func (r *WarpRegistry) authorize(operation string, hash string) error {
+	if r.config.UserAddress == "" {
+		return errors.New("WarpRegistry: user address not set")
+	}
+
+	hashBytes, err := decodeHash(hash)
+	if err != nil {
+		return err
+	}
+	infos, err := r.queryInfos("getInfoByHash", hashBytes)
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return fmt.Errorf("WarpRegistry: warp %s is not registered", hash)
+	}
+
+	if infos[0].Creator != r.config.UserAddress {
+		return &AuthorizationError{
+			Operation: operation,
+			Hash:      hash,
+			User:      r.config.UserAddress,
+			Creator:   infos[0].Creator,
+		}
+	}
+
+	return nil
+}
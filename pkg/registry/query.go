@@ -0,0 +1,216 @@
+package registry
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/abi"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// registryAbiJSON declares the views of the registry contract decoded by the SDK
+//
+//go:embed registry.abi.json
+var registryAbiJSON []byte
+
+var (
+	registryCodecOnce sync.Once
+	registryCodec     *abi.Codec
+	registryCodecErr  error
+)
+
+// loadRegistryCodec returns the codec of the registry views
+func loadRegistryCodec() (*abi.Codec, error) {
+	registryCodecOnce.Do(func() {
+		var contents types.AbiContents
+		if err := json.Unmarshal(registryAbiJSON, &contents); err != nil {
+			registryCodecErr = fmt.Errorf("WarpRegistry: invalid registry ABI: %w", err)
+			return
+		}
+		registryCodec = abi.NewCodec(&contents)
+	})
+	return registryCodec, registryCodecErr
+}
+
+// vmQueryResponse represents the response of the vm-values query endpoint
+type vmQueryResponse struct {
+	Data struct {
+		Data struct {
+			ReturnData    []string `json:"returnData"`
+			ReturnCode    string   `json:"returnCode"`
+			ReturnMessage string   `json:"returnMessage"`
+		} `json:"data"`
+	} `json:"data"`
+	Error string `json:"error"`
+}
+
+// contractAddress returns the configured registry contract address
+func (r *WarpRegistry) contractAddress() string {
+	if r.config.RegistryContract != "" {
+		return r.config.RegistryContract
+	}
+	return core.Config.DefaultRegistryContract(r.config.Env)
+}
+
+// chainAPIURL returns the configured chain API URL
+func (r *WarpRegistry) chainAPIURL() string {
+	if r.config.ChainAPIURL != "" {
+		return r.config.ChainAPIURL
+	}
+	return core.Config.DefaultChainAPIURL(r.config.Env)
+}
+
+// queryContract runs a readonly query against the registry contract and returns the decoded return data
+func (r *WarpRegistry) queryContract(funcName string, args ...[]byte) ([][]byte, error) {
+	hexArgs := make([]string, len(args))
+	for i, arg := range args {
+		hexArgs[i] = hex.EncodeToString(arg)
+	}
+
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"scAddress": r.contractAddress(),
+		"funcName":  funcName,
+		"args":      hexArgs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s/vm-values/query", r.chainAPIURL())
+	resp, err := utils.NewHTTPClient(r.config).Post(apiURL, "application/json", bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("WarpRegistry: query %s failed: %s", funcName, resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var queryResponse vmQueryResponse
+	if err := json.Unmarshal(body, &queryResponse); err != nil {
+		return nil, err
+	}
+	if queryResponse.Error != "" {
+		return nil, fmt.Errorf("WarpRegistry: query %s failed: %s", funcName, queryResponse.Error)
+	}
+
+	result := queryResponse.Data.Data
+	if result.ReturnCode != "ok" {
+		return nil, fmt.Errorf("WarpRegistry: query %s failed: %s %s", funcName, result.ReturnCode, result.ReturnMessage)
+	}
+
+	returnData := make([][]byte, len(result.ReturnData))
+	for i, data := range result.ReturnData {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, fmt.Errorf("WarpRegistry: invalid return data: %w", err)
+		}
+		returnData[i] = decoded
+	}
+
+	return returnData, nil
+}
+
+// queryInfos runs a registry view returning registry entries, see InfoView in registry.abi.json.
+// Views returning a single optional entry return no entry when the registry does not know the key.
+func (r *WarpRegistry) queryInfos(funcName string, args ...[]byte) ([]*types.RegistryInfo, error) {
+	returnData, err := r.queryContract(funcName, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	codec, err := loadRegistryCodec()
+	if err != nil {
+		return nil, err
+	}
+	values, err := codec.DecodeOutputs(funcName, returnData)
+	if err != nil {
+		return nil, fmt.Errorf("WarpRegistry: %w", err)
+	}
+
+	var views []interface{}
+	switch value := values[0].(type) {
+	case nil:
+		return []*types.RegistryInfo{}, nil
+	case []interface{}:
+		views = value
+	default:
+		views = []interface{}{value}
+	}
+
+	infos := make([]*types.RegistryInfo, 0, len(views))
+	for _, view := range views {
+		info, err := decodeRegistryInfo(view)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// decodeRegistryInfo converts a decoded InfoView to registry info
+func decodeRegistryInfo(view interface{}) (*types.RegistryInfo, error) {
+	fields, ok := view.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("WarpRegistry: invalid registry info %v", view)
+	}
+
+	trust := types.TrustStatus(optionalString(fields["trust"]))
+	if trust == "" {
+		trust = types.Unverified
+	}
+
+	createdAt, _ := fields["created_at"].(uint64)
+	creator, _ := fields["owner"].(string)
+	hash, _ := fields["hash"].([]byte)
+
+	return &types.RegistryInfo{
+		Hash:      hex.EncodeToString(hash),
+		Alias:     optionalStringPtr(fields["alias"]),
+		Trust:     trust,
+		Creator:   creator,
+		CreatedAt: int64(createdAt),
+		Brand:     optionalHex(fields["brand"]),
+		Upgrade:   optionalHex(fields["upgrade"]),
+	}, nil
+}
+
+// optionalString returns the string of a decoded bytes value, or "" if it is absent
+func optionalString(value interface{}) string {
+	data, _ := value.([]byte)
+	return string(data)
+}
+
+// optionalStringPtr returns the string of a decoded bytes value, or nil if it is absent or empty
+func optionalStringPtr(value interface{}) *string {
+	if data, _ := value.([]byte); len(data) > 0 {
+		result := string(data)
+		return &result
+	}
+	return nil
+}
+
+// optionalHex returns the hex encoding of a decoded bytes value, or nil if it is absent or empty
+func optionalHex(value interface{}) *string {
+	if data, _ := value.([]byte); len(data) > 0 {
+		result := hex.EncodeToString(data)
+		return &result
+	}
+	return nil
+}
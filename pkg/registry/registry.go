@@ -5,12 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
+	"math/big"
 	"net/http"
 	"net/url"
 
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/builder"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/cache"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/validator"
 )
 
 // RegistryResult represents the result of a registry query
@@ -19,83 +25,55 @@ type RegistryResult struct {
 	Brand        *types.Brand        `json:"brand"`
 }
 
+// RegistryConfig represents the configuration of the registry contract
+type RegistryConfig struct {
+	UnitPrice *big.Int `json:"unitPrice"` // registration fee unit, in the smallest EGLD denomination
+	Admins    []string `json:"admins"`
+}
+
 // WarpRegistry provides functionality for interacting with the warp registry
 type WarpRegistry struct {
 	config types.WarpConfig
 	cache  *cache.WarpCache
+	brands *builder.BrandBuilder
 }
 
 // NewWarpRegistry creates a new WarpRegistry instance
 func NewWarpRegistry(config types.WarpConfig) *WarpRegistry {
+	return NewWarpRegistryWithCache(config, cache.NewWarpCache())
+}
+
+// NewWarpRegistryWithCache creates a new WarpRegistry instance caching registry entries and brands in
+// an existing cache, e.g. the cache of the component that owns it
+func NewWarpRegistryWithCache(config types.WarpConfig, warpCache *cache.WarpCache) *WarpRegistry {
 	return &WarpRegistry{
 		config: config,
-		cache:  cache.NewWarpCache(),
+		cache:  warpCache,
+		brands: builder.NewBrandBuilderWithCache(config, warpCache),
 	}
 }
 
 // GetInfoByHash gets registry information by transaction hash
-func (r *WarpRegistry) GetInfoByHash(hash string) (*RegistryResult, error) {
+func (r *WarpRegistry) GetInfoByHash(hash string) (result *RegistryResult, err error) {
+	done := observability.Track(r.config.Observer, observability.OpRegistryLookup, slog.String("hash", hash))
+	defer func() { done(err) }()
+
 	// Check cache
 	cachedRegistryInfo := r.cache.Get(cache.CacheKey.RegistryInfo(hash))
+	observability.RecordCacheLookup(r.config.Observer, "registry", cachedRegistryInfo != nil)
 	if cachedRegistryInfo != nil {
 		return cachedRegistryInfo.(*RegistryResult), nil
 	}
 
-	// In a real implementation, this would call the registry contract
-	// For this example, we'll just make a simulated HTTP request
-	contractAddress := r.config.RegistryContract
-	if contractAddress == "" {
-		contractAddress = core.Config.DefaultRegistryContract(r.config.Env)
-	}
-
-	// Example API call to get registry info
-	chainAPIURL := r.config.ChainAPIURL
-	if chainAPIURL == "" {
-		chainAPIURL = core.Config.DefaultChainAPIURL(r.config.Env)
-	}
-
-	// Construct a URL for querying registry info
-	// This is a simplified example; in reality, you would call the contract directly
-	apiURL := fmt.Sprintf("%s/vm-values/query", chainAPIURL)
-
-	// Prepare the request body - this is a simplified example
-	requestBody, err := json.Marshal(map[string]interface{}{
-		"scAddress": contractAddress,
-		"funcName":  "getWarpByHash",
-		"args":      []string{hash},
-	})
+	hashBytes, err := decodeHash(hash)
 	if err != nil {
 		return nil, err
 	}
 
-	// Make the HTTP request
-	resp, err := http.Post(apiURL, "application/json", nil)
-	if err != nil {
+	result, err = r.getInfo("getInfoByHash", hashBytes)
+	if err != nil || result == nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get registry info: %s", resp.Status)
-	}
-
-	// In a real implementation, you would parse the response
-	// For this example, we'll create a simulated response
-	registryInfo := &types.RegistryInfo{
-		Hash:      hash,
-		Alias:     nil,
-		Trust:     types.Unverified,
-		Creator:   "erd1...",
-		CreatedAt: 1625097600,
-		Brand:     nil,
-		Upgrade:   nil,
-	}
-
-	// Create the result
-	result := &RegistryResult{
-		RegistryInfo: registryInfo,
-		Brand:        nil,
-	}
 
 	// Cache the result
 	if r.config.CacheTTL > 0 {
@@ -106,81 +84,66 @@ func (r *WarpRegistry) GetInfoByHash(hash string) (*RegistryResult, error) {
 }
 
 // GetInfoByAlias gets registry information by alias
-func (r *WarpRegistry) GetInfoByAlias(alias string) (*RegistryResult, error) {
+func (r *WarpRegistry) GetInfoByAlias(alias string) (result *RegistryResult, err error) {
+	done := observability.Track(r.config.Observer, observability.OpRegistryLookup, slog.String("alias", alias))
+	defer func() { done(err) }()
+
 	// Check cache
 	cachedRegistryInfo := r.cache.Get(cache.CacheKey.RegistryInfo(alias))
+	observability.RecordCacheLookup(r.config.Observer, "registry", cachedRegistryInfo != nil)
 	if cachedRegistryInfo != nil {
 		return cachedRegistryInfo.(*RegistryResult), nil
 	}
 
-	// In a real implementation, this would call the registry contract
-	// For this example, we'll just make a simulated HTTP request
-	contractAddress := r.config.RegistryContract
-	if contractAddress == "" {
-		contractAddress = core.Config.DefaultRegistryContract(r.config.Env)
-	}
-
-	// Example API call to get registry info
-	chainAPIURL := r.config.ChainAPIURL
-	if chainAPIURL == "" {
-		chainAPIURL = core.Config.DefaultChainAPIURL(r.config.Env)
-	}
-
-	// Construct a URL for querying registry info
-	// This is a simplified example; in reality, you would call the contract directly
-	apiURL := fmt.Sprintf("%s/vm-values/query", chainAPIURL)
-
-	// Prepare the request body - this is a simplified example
-	requestBody, err := json.Marshal(map[string]interface{}{
-		"scAddress": contractAddress,
-		"funcName":  "getWarpByAlias",
-		"args":      []string{alias},
-	})
-	if err != nil {
+	result, err = r.getInfo("getInfoByAlias", []byte(alias))
+	if err != nil || result == nil {
 		return nil, err
 	}
 
-	// Make the HTTP request
-	resp, err := http.Post(apiURL, "application/json", nil)
-	if err != nil {
-		return nil, err
+	// Cache the result
+	if r.config.CacheTTL > 0 {
+		r.cache.Set(cache.CacheKey.RegistryInfo(alias), result, r.config.CacheTTL)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get registry info: %s", resp.Status)
-	}
+	return result, nil
+}
 
-	// In a real implementation, you would parse the response
-	// For this example, we'll create a simulated response
-	hash := "123456789abcdef"
-	aliasPtr := alias
-	registryInfo := &types.RegistryInfo{
-		Hash:      hash,
-		Alias:     &aliasPtr,
-		Trust:     types.Unverified,
-		Creator:   "erd1...",
-		CreatedAt: 1625097600,
-		Brand:     nil,
-		Upgrade:   nil,
+// getInfo queries a registry info view and fetches the linked brand.
+// It returns nil if the registry has no entry for the key.
+func (r *WarpRegistry) getInfo(funcName string, key []byte) (*RegistryResult, error) {
+	infos, err := r.queryInfos(funcName, key)
+	if err != nil || len(infos) == 0 {
+		return nil, err
 	}
 
-	// Create the result
 	result := &RegistryResult{
-		RegistryInfo: registryInfo,
+		RegistryInfo: infos[0],
 		Brand:        nil,
 	}
 
-	// Cache the result
-	if r.config.CacheTTL > 0 {
-		r.cache.Set(cache.CacheKey.RegistryInfo(alias), result, r.config.CacheTTL)
+	// A brand that cannot be fetched does not make the entry unusable, it is returned without brand
+	if infos[0].Brand != nil {
+		if brand, err := r.brands.CreateFromTransactionHash(*infos[0].Brand, r.brandCacheConfig()); err == nil {
+			result.Brand = brand
+		}
 	}
 
 	return result, nil
 }
 
+// brandCacheConfig returns the cache settings for linked brands, or nil when caching is disabled
+func (r *WarpRegistry) brandCacheConfig() *types.WarpCacheConfig {
+	if r.config.CacheTTL <= 0 {
+		return nil
+	}
+	return &types.WarpCacheConfig{TTL: r.config.CacheTTL}
+}
+
 // Search searches the registry for warps
-func (r *WarpRegistry) Search(query string) (*types.WarpSearchResult, error) {
+func (r *WarpRegistry) Search(query string) (result *types.WarpSearchResult, err error) {
+	done := observability.Track(r.config.Observer, observability.OpRegistrySearch)
+	defer func() { done(err) }()
+
 	// In a real implementation, this would call the index API
 	indexURL := r.config.IndexURL
 	if indexURL == "" {
@@ -204,7 +167,7 @@ func (r *WarpRegistry) Search(query string) (*types.WarpSearchResult, error) {
 	}
 
 	// Make the HTTP request
-	resp, err := http.Get(searchURL)
+	resp, err := utils.NewHTTPClient(r.config).Get(searchURL)
 	if err != nil {
 		return nil, err
 	}
@@ -219,56 +182,98 @@ func (r *WarpRegistry) Search(query string) (*types.WarpSearchResult, error) {
 		return nil, err
 	}
 
-	var result types.WarpSearchResult
-	if err := json.Unmarshal(body, &result); err != nil {
+	result = &types.WarpSearchResult{}
+	if err := json.Unmarshal(body, result); err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	return result, nil
 }
 
-// RegisterAlias registers an alias for a warp
-func (r *WarpRegistry) RegisterAlias(hash string, alias string) (string, error) {
-	// In a real implementation, this would call the registry contract
-	if r.config.UserAddress == "" {
-		return "", errors.New("WarpRegistry: user address not set")
+// RegisterWarp creates a transaction registering an inscribed warp, optionally with an alias.
+// Registering costs the registry unit price, with or without alias.
+func (r *WarpRegistry) RegisterWarp(hash string, alias *string) (*types.Transaction, error) {
+	hashBytes, err := decodeHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, err
 	}
 
-	contractAddress := r.config.RegistryContract
-	if contractAddress == "" {
-		contractAddress = core.Config.DefaultRegistryContract(r.config.Env)
+	args := [][]byte{hashBytes}
+	if alias != nil && *alias != "" {
+		args = append(args, []byte(*alias))
 	}
 
-	// For this example, we'll just return a simulated transaction hash
-	return "tx-hash-for-register-alias", nil
+	return r.createTransaction("registerWarp", config.UnitPrice, args...)
 }
 
-// RegisterBrand registers a brand for a warp
-func (r *WarpRegistry) RegisterBrand(brand *types.Brand) (string, error) {
-	// In a real implementation, this would call the registry contract
-	if r.config.UserAddress == "" {
-		return "", errors.New("WarpRegistry: user address not set")
+// RegisterAlias creates a transaction assigning an alias to a registered warp
+func (r *WarpRegistry) RegisterAlias(hash string, alias string) (*types.Transaction, error) {
+	hashBytes, err := decodeHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if alias == "" {
+		return nil, errors.New("WarpRegistry: alias is required")
 	}
 
-	contractAddress := r.config.RegistryContract
-	if contractAddress == "" {
-		contractAddress = core.Config.DefaultRegistryContract(r.config.Env)
+	if err := r.authorize("alias assignment", hash); err != nil {
+		return nil, err
 	}
 
-	// Validate the brand
-	if brand.Protocol == "" {
-		return "", errors.New("WarpRegistry: brand protocol is required")
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, err
 	}
-	if brand.Name == "" {
-		return "", errors.New("WarpRegistry: brand name is required")
+
+	return r.createTransaction("assignAlias", config.UnitPrice, hashBytes, []byte(alias))
+}
+
+// RegisterBrand creates a transaction publishing an inscribed brand.
+// The brand must have been inscribed first, its Meta.Hash identifies it in the registry.
+func (r *WarpRegistry) RegisterBrand(brand *types.Brand) (*types.Transaction, error) {
+	if brand == nil {
+		return nil, errors.New("WarpRegistry: brand is nil")
 	}
-	if brand.Description == "" {
-		return "", errors.New("WarpRegistry: brand description is required")
+
+	if err := validator.NewWarpValidator(r.config).ValidateBrand(brand); err != nil {
+		return nil, err
 	}
-	if brand.Logo == "" {
-		return "", errors.New("WarpRegistry: brand logo is required")
+	if brand.Meta == nil || brand.Meta.Hash == "" {
+		return nil, errors.New("WarpRegistry: brand must be inscribed before it can be registered")
 	}
 
-	// For this example, we'll just return a simulated transaction hash
-	return "tx-hash-for-register-brand", nil
-} 
\ No newline at end of file
+	hashBytes, err := decodeHash(brand.Meta.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := r.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.createTransaction("publishBrand", config.UnitPrice, hashBytes)
+}
+
+// SetWarpBrand creates a transaction linking a registered warp to a published brand
+func (r *WarpRegistry) SetWarpBrand(warpHash string, brandHash string) (*types.Transaction, error) {
+	warpHashBytes, err := decodeHash(warpHash)
+	if err != nil {
+		return nil, err
+	}
+	brandHashBytes, err := decodeHash(brandHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.authorize("branding", warpHash); err != nil {
+		return nil, err
+	}
+
+	return r.createTransaction("setWarpBrand", nil, warpHashBytes, brandHashBytes)
+}
@@ -0,0 +1,412 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestGetInfoByHash(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	result, err := NewWarpRegistry(server.Config()).GetInfoByHash(server.WarpHash)
+	if err != nil {
+		t.Fatalf("GetInfoByHash() error = %v", err)
+	}
+	if result == nil || result.RegistryInfo == nil {
+		t.Fatal("GetInfoByHash() = nil, expected registry info")
+	}
+
+	info := result.RegistryInfo
+	if info.Hash != server.WarpHash {
+		t.Errorf("Hash = %s, expected %s", info.Hash, server.WarpHash)
+	}
+	if info.Alias == nil || *info.Alias != warptest.WarpAlias {
+		t.Errorf("Alias = %v, expected %s", info.Alias, warptest.WarpAlias)
+	}
+	if info.Trust != types.Verified {
+		t.Errorf("Trust = %s, expected %s", info.Trust, types.Verified)
+	}
+	if info.Creator != warptest.Creator {
+		t.Errorf("Creator = %s, expected %s", info.Creator, warptest.Creator)
+	}
+	if info.CreatedAt != warptest.FixtureTimestamp {
+		t.Errorf("CreatedAt = %d, expected %d", info.CreatedAt, warptest.FixtureTimestamp)
+	}
+	if info.Upgrade != nil {
+		t.Errorf("Upgrade = %s, expected nil", *info.Upgrade)
+	}
+
+	if result.Brand == nil || result.Brand.Name != warptest.FixtureBrand().Name {
+		t.Errorf("Brand = %v, expected %s", result.Brand, warptest.FixtureBrand().Name)
+	}
+	if result.Brand != nil && (result.Brand.Meta == nil || result.Brand.Meta.Hash != server.BrandHash) {
+		t.Errorf("Brand.Meta = %v, expected hash %s", result.Brand.Meta, server.BrandHash)
+	}
+}
+
+func TestGetInfoByHashInvalid(t *testing.T) {
+	// Hashes are checked as by the other registry entry points
+	registry := NewWarpRegistry(types.WarpConfig{})
+	for _, hash := range []string{"", "not-hex"} {
+		_, err := registry.GetInfoByHash(hash)
+		_, expected := decodeHash(hash)
+		if err == nil || expected == nil || err.Error() != expected.Error() {
+			t.Errorf("GetInfoByHash(%q) error = %v, expected %v", hash, err, expected)
+		}
+	}
+}
+
+func TestGetInfoByAlias(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	warpRegistry := NewWarpRegistry(server.Config())
+
+	result, err := warpRegistry.GetInfoByAlias(warptest.WarpAlias)
+	if err != nil {
+		t.Fatalf("GetInfoByAlias() error = %v", err)
+	}
+	if result == nil || result.RegistryInfo.Hash != server.WarpHash {
+		t.Errorf("GetInfoByAlias() = %v, expected hash %s", result, server.WarpHash)
+	}
+
+	result, err = warpRegistry.GetInfoByAlias("unknown-alias")
+	if err != nil {
+		t.Fatalf("GetInfoByAlias() unknown error = %v", err)
+	}
+	if result != nil {
+		t.Errorf("GetInfoByAlias() unknown = %v, expected nil", result)
+	}
+}
+
+// infoView is a getInfoByHash result as encoded by the registry contract: hash, alias "myapp",
+// trust "verified", owner, created_at 1705030080, no brand and an upgrade
+var infoView = "00000020" + strings.Repeat("aa", 32) +
+	"01" + "00000005" + "6d79617070" +
+	"00000008" + "7665726966696564" +
+	"0139472eff6886771a982f3083da5d421f24c29181e63888228dc81ca60d69e1" +
+	"0000000065a0b1c0" +
+	"00" +
+	"01" + "00000020" + strings.Repeat("bb", 32)
+
+// newQueryServer serves the given return data for every vm-values query
+func newQueryServer(returnData ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoded := make([]string, len(returnData))
+		for i, data := range returnData {
+			decoded, _ := hex.DecodeString(data)
+			encoded[i] = base64.StdEncoding.EncodeToString(decoded)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{"returnData": encoded, "returnCode": "ok", "returnMessage": ""},
+			},
+		})
+	}))
+}
+
+func TestGetInfoByHashLayout(t *testing.T) {
+	server := newQueryServer(infoView)
+	defer server.Close()
+
+	result, err := NewWarpRegistry(types.WarpConfig{ChainAPIURL: server.URL}).GetInfoByHash(strings.Repeat("aa", 32))
+	if err != nil {
+		t.Fatalf("GetInfoByHash() error = %v", err)
+	}
+
+	alias, upgrade := "myapp", strings.Repeat("bb", 32)
+	expected := types.RegistryInfo{
+		Hash:      strings.Repeat("aa", 32),
+		Alias:     &alias,
+		Trust:     types.Verified,
+		Creator:   "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th",
+		CreatedAt: 1705030080,
+		Upgrade:   &upgrade,
+	}
+	if result == nil || !reflect.DeepEqual(*result.RegistryInfo, expected) {
+		t.Errorf("GetInfoByHash() = %+v, expected %+v", result, expected)
+	}
+
+	// An empty optional result means the registry has no entry
+	empty := newQueryServer()
+	defer empty.Close()
+	if result, err := NewWarpRegistry(types.WarpConfig{ChainAPIURL: empty.URL}).GetInfoByHash(strings.Repeat("aa", 32)); err != nil || result != nil {
+		t.Errorf("GetInfoByHash() = %v, %v, expected no entry", result, err)
+	}
+
+	truncated := newQueryServer(infoView[:100])
+	defer truncated.Close()
+	if _, err := NewWarpRegistry(types.WarpConfig{ChainAPIURL: truncated.URL}).GetInfoByHash(strings.Repeat("aa", 32)); err == nil {
+		t.Error("GetInfoByHash() error = nil, expected a truncated entry to be rejected")
+	}
+}
+
+func TestGetInfoByHashMissingBrand(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	missing := strings.Repeat("cc", 32)
+	server.AddRegistryInfo(types.RegistryInfo{Hash: server.WarpHash, Trust: types.Verified, Creator: warptest.Creator, Brand: &missing})
+
+	result, err := NewWarpRegistry(server.Config()).GetInfoByHash(server.WarpHash)
+	if err != nil {
+		t.Fatalf("GetInfoByHash() error = %v", err)
+	}
+	if result == nil || result.RegistryInfo.Brand == nil || *result.RegistryInfo.Brand != missing || result.Brand != nil {
+		t.Errorf("GetInfoByHash() = %+v, expected the entry without its missing brand", result)
+	}
+}
+
+func TestRegisterWarp(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	alias := "my-alias"
+	tx, err := NewWarpRegistry(server.Config()).RegisterWarp(server.WarpHash, &alias)
+	if err != nil {
+		t.Fatalf("RegisterWarp() error = %v", err)
+	}
+
+	expectedData := "registerWarp@" + server.WarpHash + "@" + hex.EncodeToString([]byte(alias))
+	if string(tx.Data) != expectedData {
+		t.Errorf("Data = %s, expected %s", tx.Data, expectedData)
+	}
+	if tx.Value != warptest.RegistryUnitPrice {
+		t.Errorf("Value = %s, expected the unit price", tx.Value)
+	}
+	if tx.Receiver != warptest.RegistryContract || tx.Sender != warptest.Creator {
+		t.Errorf("Receiver, Sender = %s, %s", tx.Receiver, tx.Sender)
+	}
+	if tx.ChainID != "D" || tx.GasLimit == 0 {
+		t.Errorf("ChainID, GasLimit = %s, %d", tx.ChainID, tx.GasLimit)
+	}
+}
+
+func TestRegisterAlias(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	tx, err := NewWarpRegistry(server.Config()).RegisterAlias(server.WarpHash, "my-alias")
+	if err != nil {
+		t.Fatalf("RegisterAlias() error = %v", err)
+	}
+
+	if !strings.HasPrefix(string(tx.Data), "assignAlias@"+server.WarpHash+"@") {
+		t.Errorf("Data = %s, expected an assignAlias call", tx.Data)
+	}
+	if tx.Value != warptest.RegistryUnitPrice {
+		t.Errorf("Value = %s, expected %s", tx.Value, warptest.RegistryUnitPrice)
+	}
+}
+
+func TestRegisterBrand(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	warpRegistry := NewWarpRegistry(server.Config())
+	brand := warptest.FixtureBrand()
+
+	if _, err := warpRegistry.RegisterBrand(&brand); err == nil {
+		t.Error("RegisterBrand() error = nil, expected an error for a brand that was not inscribed")
+	}
+
+	brand.Meta = &types.BrandMeta{Hash: server.BrandHash}
+	tx, err := warpRegistry.RegisterBrand(&brand)
+	if err != nil {
+		t.Fatalf("RegisterBrand() error = %v", err)
+	}
+	if string(tx.Data) != "publishBrand@"+server.BrandHash {
+		t.Errorf("Data = %s, expected a publishBrand call", tx.Data)
+	}
+}
+
+func TestSetWarpBrand(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	tx, err := NewWarpRegistry(server.Config()).SetWarpBrand(server.WarpHash, server.BrandHash)
+	if err != nil {
+		t.Fatalf("SetWarpBrand() error = %v", err)
+	}
+	if string(tx.Data) != "setWarpBrand@"+server.WarpHash+"@"+server.BrandHash || tx.Value != "0" {
+		t.Errorf("Data, Value = %s, %s", tx.Data, tx.Value)
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	config, err := NewWarpRegistry(server.Config()).GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if config.UnitPrice.String() != warptest.RegistryUnitPrice {
+		t.Errorf("UnitPrice = %s, expected %s", config.UnitPrice, warptest.RegistryUnitPrice)
+	}
+	if len(config.Admins) != 1 || config.Admins[0] != warptest.Creator {
+		t.Errorf("Admins = %v, expected [%s]", config.Admins, warptest.Creator)
+	}
+
+	// Changing a returned configuration does not affect the cached one
+	cachedConfig := server.Config()
+	cachedConfig.CacheTTL = 60
+	warpRegistry := NewWarpRegistry(cachedConfig)
+	first, err := warpRegistry.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	first.UnitPrice.SetInt64(0)
+	first.Admins[0] = "changed"
+	second, err := warpRegistry.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if second.UnitPrice.String() != warptest.RegistryUnitPrice || second.Admins[0] != warptest.Creator {
+		t.Errorf("GetConfig() = %v, expected the unchanged cached configuration", second)
+	}
+}
+
+func TestManagementAuthorization(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	config := server.Config()
+	config.UserAddress = "erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3"
+	warpRegistry := NewWarpRegistry(config)
+
+	_, err := warpRegistry.Unregister(UnregisterRequest{Hash: server.WarpHash})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Unregister() error = %v, expected ErrUnauthorized", err)
+	}
+
+	_, err = warpRegistry.TransferOwnership(TransferOwnershipRequest{Hash: server.WarpHash, NewOwner: config.UserAddress})
+	var authErr *AuthorizationError
+	if !errors.As(err, &authErr) || authErr.Creator != warptest.Creator {
+		t.Errorf("TransferOwnership() error = %v, expected an AuthorizationError", err)
+	}
+
+	_, err = warpRegistry.RegisterAlias(server.WarpHash, "stolen-alias")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("RegisterAlias() error = %v, expected ErrUnauthorized", err)
+	}
+}
+
+func TestManagementAuthorizationBypassesCache(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	config := server.Config()
+	config.CacheTTL = 60
+	warpRegistry := NewWarpRegistry(config)
+	if _, err := warpRegistry.GetInfoByHash(server.WarpHash); err != nil {
+		t.Fatalf("GetInfoByHash() error = %v", err)
+	}
+
+	// The warp changes owner after its entry was cached
+	newOwner := "erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3"
+	server.AddRegistryInfo(types.RegistryInfo{Hash: server.WarpHash, Trust: types.Verified, Creator: newOwner})
+
+	if _, err := warpRegistry.Unregister(UnregisterRequest{Hash: server.WarpHash}); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Unregister() error = %v, expected ErrUnauthorized for the former owner", err)
+	}
+}
+
+func TestPublishUpgrade(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	upgrade := warptest.FixtureWarp()
+	upgrade.Title = "Fixture Warp v2"
+	upgradeHash := server.AddWarp(upgrade, warptest.Creator)
+	tx, err := NewWarpRegistry(server.Config()).PublishUpgrade(PublishUpgradeRequest{
+		Hash:        server.WarpHash,
+		UpgradeHash: upgradeHash,
+	})
+	if err != nil {
+		t.Fatalf("PublishUpgrade() error = %v", err)
+	}
+	if string(tx.Data) != "publishUpgrade@"+server.WarpHash+"@"+upgradeHash {
+		t.Errorf("Data = %s, expected a publishUpgrade call", tx.Data)
+	}
+
+	_, err = NewWarpRegistry(server.Config()).PublishUpgrade(PublishUpgradeRequest{
+		Hash:        upgradeHash,
+		UpgradeHash: server.WarpHash,
+	})
+	if err == nil {
+		t.Error("PublishUpgrade() error = nil, expected an error for an unregistered warp")
+	}
+}
+
+func TestListByCreator(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	for _, title := range []string{"Second", "Third"} {
+		warp := warptest.FixtureWarp()
+		warp.Title = title
+		server.AddWarp(warp, warptest.Creator)
+	}
+
+	warpRegistry := NewWarpRegistry(server.Config())
+
+	first, err := warpRegistry.ListByCreator(warptest.Creator, ListOptions{Size: 3})
+	if err != nil {
+		t.Fatalf("ListByCreator() error = %v", err)
+	}
+	second, err := warpRegistry.ListByCreator(warptest.Creator, ListOptions{From: 3, Size: 3})
+	if err != nil {
+		t.Fatalf("ListByCreator() error = %v", err)
+	}
+
+	if !first.HasMore || second.HasMore {
+		t.Errorf("HasMore = %v, %v, expected true, false", first.HasMore, second.HasMore)
+	}
+
+	// The brand, warp and ABI fixtures and the two warps make five inscriptions, the last three fill a page
+	last, err := warpRegistry.ListByCreator(warptest.Creator, ListOptions{From: 2, Size: 3})
+	if err != nil {
+		t.Fatalf("ListByCreator() error = %v", err)
+	}
+	if last.HasMore {
+		t.Error("HasMore = true, expected an exactly full last page to have no more")
+	}
+
+	warps := append(first.Warps, second.Warps...)
+	brands := append(first.Brands, second.Brands...)
+	if len(warps) != 3 || len(brands) != 1 {
+		t.Fatalf("ListByCreator() = %d warps, %d brands, expected 3 and 1", len(warps), len(brands))
+	}
+
+	var registered int
+	for _, warp := range warps {
+		if warp.Warp.Meta == nil || warp.Warp.Meta.Creator != warptest.Creator {
+			t.Errorf("Warp.Meta = %v, expected creator %s", warp.Warp.Meta, warptest.Creator)
+		}
+		if warp.RegistryInfo != nil {
+			registered++
+		}
+	}
+	if registered != 1 {
+		t.Errorf("registered warps = %d, expected 1", registered)
+	}
+
+	if len(first.Aliases) != 1 || first.Aliases[0] != warptest.WarpAlias {
+		t.Errorf("Aliases = %v, expected [%s]", first.Aliases, warptest.WarpAlias)
+	}
+	if len(first.PublishedBrands) != 1 || first.PublishedBrands[0].Meta == nil || first.PublishedBrands[0].Meta.Hash != server.BrandHash {
+		t.Errorf("PublishedBrands = %v, expected the brand %s", first.PublishedBrands, server.BrandHash)
+	}
+}
@@ -0,0 +1,61 @@
+package registry
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// baseGasLimit is the gas limit of registry calls before the data cost
+const baseGasLimit uint64 = 10000000
+
+// createTransaction creates an unsigned call of the registry contract from the user address
+func (r *WarpRegistry) createTransaction(funcName string, value *big.Int, args ...[]byte) (*types.Transaction, error) {
+	if r.config.UserAddress == "" {
+		return nil, errors.New("WarpRegistry: user address not set")
+	}
+	if !address.IsValid(r.config.UserAddress) {
+		return nil, fmt.Errorf("WarpRegistry: invalid user address %s", r.config.UserAddress)
+	}
+
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, funcName)
+	for _, arg := range args {
+		parts = append(parts, hex.EncodeToString(arg))
+	}
+	data := []byte(strings.Join(parts, "@"))
+
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	return &types.Transaction{
+		Value:    value.String(),
+		Receiver: r.contractAddress(),
+		Sender:   r.config.UserAddress,
+		GasPrice: constants.WarpConstants.Transaction.GasPrice,
+		GasLimit: baseGasLimit + constants.WarpConstants.Transaction.GasPerDataByte*uint64(len(data)),
+		Data:     data,
+		ChainID:  utils.GetChainID(r.config.Env),
+		Version:  constants.WarpConstants.Transaction.Version,
+	}, nil
+}
+
+// decodeHash decodes a hex transaction hash into bytes
+func decodeHash(hash string) ([]byte, error) {
+	if hash == "" {
+		return nil, errors.New("WarpRegistry: hash is required")
+	}
+	decoded, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, fmt.Errorf("WarpRegistry: invalid hash %s: %w", hash, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,309 @@
+// Package security analyzes warps for phishing and security risks before they are rendered
+package security
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// Severity represents how dangerous a finding is
+type Severity string
+
+const (
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// weights maps each severity to the points it adds to the risk score
+var weights = map[Severity]int{
+	SeverityLow:      5,
+	SeverityMedium:   15,
+	SeverityHigh:     30,
+	SeverityCritical: 100,
+}
+
+// Level represents the overall risk of a warp, derived from its score
+type Level string
+
+const (
+	LevelNone     Level = "none"
+	LevelLow      Level = "low"
+	LevelMedium   Level = "medium"
+	LevelHigh     Level = "high"
+	LevelCritical Level = "critical"
+)
+
+// Finding codes reported by the analyzer
+const (
+	CodeInsecureURL        = "url.insecure"
+	CodeInvalidURL         = "url.invalid"
+	CodeUnsafeScheme       = "url.scheme"
+	CodeOffBrandDomain     = "url.offBrand"
+	CodeLookalikeDomain    = "url.lookalike"
+	CodePunycodeDomain     = "url.punycode"
+	CodeUnboundedAmount    = "amount.unbounded"
+	CodeUnlimitedTransfer  = "amount.unlimited"
+	CodeUnverifiedContract = "contract.unverified"
+	CodeUnknownContract    = "contract.unknown"
+	CodeBlacklisted        = "trust.blacklisted"
+	CodeUnverifiedTrust    = "trust.unverified"
+	CodeUnregistered       = "trust.unregistered"
+)
+
+// Finding represents a single risk, with the points it adds to the score
+type Finding struct {
+	Path     string   `json:"path"` // JSON pointer to the risky value, "" for the warp itself
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	Weight   int      `json:"weight"`
+}
+
+// String returns the finding as "[severity] path: message"
+func (f Finding) String() string {
+	if f.Path == "" {
+		return fmt.Sprintf("[%s] %s", f.Severity, f.Message)
+	}
+	return fmt.Sprintf("[%s] %s: %s", f.Severity, f.Path, f.Message)
+}
+
+// Report represents the risks found in a warp. The score is the sum of the finding weights, from 0 to 100.
+type Report struct {
+	Score    int       `json:"score"`
+	Level    Level     `json:"level"`
+	Findings []Finding `json:"findings"`
+}
+
+// String explains the report, one finding per line, the most severe first
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "risk %s (%d/100)", r.Level, r.Score)
+	for _, finding := range r.Findings {
+		b.WriteString("\n  " + finding.String())
+	}
+	return b.String()
+}
+
+// add adds a finding to the report
+func (r *Report) add(path string, severity Severity, code string, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{
+		Path:     path,
+		Severity: severity,
+		Code:     code,
+		Message:  fmt.Sprintf(format, args...),
+		Weight:   weights[severity],
+	})
+}
+
+// finish sorts the findings and computes the score and level
+func (r *Report) finish() {
+	rank := map[Severity]int{SeverityCritical: 0, SeverityHigh: 1, SeverityMedium: 2, SeverityLow: 3}
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		return rank[r.Findings[i].Severity] < rank[r.Findings[j].Severity]
+	})
+
+	r.Score = 0
+	critical := false
+	for _, finding := range r.Findings {
+		r.Score += finding.Weight
+		critical = critical || finding.Severity == SeverityCritical
+	}
+	if r.Score > 100 {
+		r.Score = 100
+	}
+
+	switch {
+	case critical || r.Score >= 75:
+		r.Level = LevelCritical
+	case r.Score >= 50:
+		r.Level = LevelHigh
+	case r.Score >= 25:
+		r.Level = LevelMedium
+	case r.Score > 0:
+		r.Level = LevelLow
+	default:
+		r.Level = LevelNone
+	}
+}
+
+// Subject is what the analyzer inspects: a warp and what is known about it
+type Subject struct {
+	Warp         *types.Warp
+	RegistryInfo *types.RegistryInfo            // nil if the warp is not registered
+	Brand        *types.Brand                   // nil if the warp has no brand
+	Contracts    map[string]*types.WarpContract // known contracts, keyed by address, e.g. from detection results
+}
+
+// WarpAnalyzer scores the phishing and security risks of warps
+type WarpAnalyzer struct {
+	config       types.WarpConfig
+	knownDomains []string
+}
+
+// NewWarpAnalyzer creates a new WarpAnalyzer instance. Link domains that imitate the known domains,
+// the client domain or the brand domain are reported as lookalikes.
+func NewWarpAnalyzer(config types.WarpConfig, knownDomains ...string) *WarpAnalyzer {
+	domains := append([]string{"multiversx.com", "usewarp.to"}, knownDomains...)
+	if clientURL, err := url.Parse(config.ClientURL); err == nil && clientURL.Hostname() != "" {
+		domains = append(domains, registrableDomain(clientURL.Hostname()))
+	}
+
+	return &WarpAnalyzer{
+		config:       config,
+		knownDomains: domains,
+	}
+}
+
+// Analyze reports the risks of a warp
+func (a *WarpAnalyzer) Analyze(subject Subject) *Report {
+	report := &Report{Findings: []Finding{}}
+	defer report.finish()
+
+	a.analyzeTrust(report, subject.RegistryInfo)
+	if subject.Warp == nil {
+		return report
+	}
+
+	brandDomain := ""
+	if subject.Brand != nil && subject.Brand.URLs != nil && subject.Brand.URLs.Web != nil {
+		if web, err := url.Parse(*subject.Brand.URLs.Web); err == nil {
+			brandDomain = registrableDomain(web.Hostname())
+		}
+	}
+
+	for i, action := range subject.Warp.Actions {
+		path := fmt.Sprintf("/actions/%d", i)
+		switch act := action.(type) {
+		case types.WarpCollectAction:
+			a.analyzeCollect(report, path+"/destination/url", act.Destination.URL, brandDomain)
+		case types.WarpLinkAction:
+			a.analyzeLink(report, path+"/url", act.URL, brandDomain)
+		case types.WarpTransferAction:
+			analyzeAmounts(report, path, act.Transfers, act.Inputs)
+		case types.WarpContractAction:
+			analyzeAmounts(report, path, act.Transfers, act.Inputs)
+			analyzeContract(report, path+"/address", act.Address, subject.Contracts)
+		}
+	}
+
+	return report
+}
+
+// analyzeTrust reports warps that the registry blacklisted, did not verify or does not know
+func (a *WarpAnalyzer) analyzeTrust(report *Report, info *types.RegistryInfo) {
+	switch {
+	case info == nil:
+		report.add("", SeverityLow, CodeUnregistered, "the warp is not registered")
+	case info.Trust == types.Blacklisted:
+		report.add("", SeverityCritical, CodeBlacklisted, "the registry blacklisted the warp")
+	case info.Trust != types.Verified:
+		report.add("", SeverityMedium, CodeUnverifiedTrust, "the registry has not verified the warp")
+	}
+}
+
+// scriptSchemes lists the URL schemes that run or embed content in the page instead of navigating
+var scriptSchemes = map[string]bool{
+	"javascript": true,
+	"vbscript":   true,
+	"data":       true,
+}
+
+// parseWebURL parses an http or https URL, reporting URLs with other schemes or without a host
+func parseWebURL(report *Report, path string, raw string) (*url.URL, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		report.add(path, SeverityHigh, CodeInvalidURL, "%q is not a valid URL", raw)
+		return nil, false
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	switch {
+	case scriptSchemes[scheme]:
+		report.add(path, SeverityCritical, CodeUnsafeScheme, "the %s: URL runs content in the page instead of opening a website", scheme)
+		return nil, false
+	case scheme != "http" && scheme != "https":
+		report.add(path, SeverityHigh, CodeUnsafeScheme, "%q is not an http or https URL", raw)
+		return nil, false
+	case parsed.Hostname() == "":
+		report.add(path, SeverityHigh, CodeInvalidURL, "%q has no host", raw)
+		return nil, false
+	}
+	return parsed, true
+}
+
+// analyzeCollect reports collect actions posting to plain http, to a domain unrelated to the brand,
+// or to anything other than a website
+func (a *WarpAnalyzer) analyzeCollect(report *Report, path string, destination string, brandDomain string) {
+	parsed, ok := parseWebURL(report, path, destination)
+	if !ok {
+		return
+	}
+	if strings.EqualFold(parsed.Scheme, "http") {
+		report.add(path, SeverityHigh, CodeInsecureURL, "collected data is sent unencrypted to %s", parsed.Host)
+	}
+	if brandDomain != "" && registrableDomain(parsed.Hostname()) != brandDomain {
+		report.add(path, SeverityMedium, CodeOffBrandDomain, "collected data is sent to %s, unrelated to the brand domain %s", parsed.Hostname(), brandDomain)
+	}
+}
+
+// analyzeLink reports links over plain http, links to punycode or lookalike domains, and links to
+// anything other than a website
+func (a *WarpAnalyzer) analyzeLink(report *Report, path string, link string, brandDomain string) {
+	parsed, ok := parseWebURL(report, path, link)
+	if !ok {
+		return
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if strings.EqualFold(parsed.Scheme, "http") {
+		report.add(path, SeverityMedium, CodeInsecureURL, "the link to %s is not encrypted", host)
+	}
+	if strings.HasPrefix(host, "xn--") || strings.Contains(host, ".xn--") {
+		report.add(path, SeverityHigh, CodePunycodeDomain, "%s uses international characters that can imitate other domains", host)
+	}
+
+	domain := registrableDomain(host)
+	known := a.knownDomains
+	if brandDomain != "" {
+		known = append([]string{brandDomain}, known...)
+	}
+	if imitated := lookalikeOf(domain, known); imitated != "" {
+		report.add(path, SeverityHigh, CodeLookalikeDomain, "%s looks like %s", domain, imitated)
+	}
+}
+
+// analyzeAmounts reports transfers of any amount: value and transfer inputs without a maximum, and
+// token transfers without an amount
+func analyzeAmounts(report *Report, path string, transfers []types.WarpContractActionTransfer, inputs []types.WarpActionInput) {
+	for j, input := range inputs {
+		if input.Position != types.ValuePosition && input.Position != types.TransferPosition {
+			continue
+		}
+		if input.Max == nil {
+			report.add(fmt.Sprintf("%s/inputs/%d", path, j), SeverityHigh, CodeUnboundedAmount,
+				"the user can send any amount through %s, it has no maximum", input.Name)
+		}
+	}
+	for k, transfer := range transfers {
+		if transfer.Amount == nil || *transfer.Amount == "" {
+			report.add(fmt.Sprintf("%s/transfers/%d", path, k), SeverityMedium, CodeUnlimitedTransfer,
+				"the transfer of %s has no amount", transfer.Token)
+		}
+	}
+}
+
+// analyzeContract reports contract actions on contracts that are unverified or unknown
+func analyzeContract(report *Report, path string, contractAddress string, contracts map[string]*types.WarpContract) {
+	contract := contracts[contractAddress]
+	switch {
+	case contract == nil:
+		report.add(path, SeverityLow, CodeUnknownContract, "the verification of %s is unknown", contractAddress)
+	case !contract.Verified:
+		report.add(path, SeverityHigh, CodeUnverifiedContract, "the code of %s is not verified", contractAddress)
+	}
+}
@@ -0,0 +1,125 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestAnalyzeSafeWarp(t *testing.T) {
+	warp := warptest.FixtureWarp()
+	brand := warptest.FixtureBrand()
+
+	report := NewWarpAnalyzer(types.WarpConfig{}).Analyze(Subject{
+		Warp:         &warp,
+		RegistryInfo: &types.RegistryInfo{Trust: types.Verified},
+		Brand:        &brand,
+		Contracts:    map[string]*types.WarpContract{warptest.RegistryContract: {Address: warptest.RegistryContract, Verified: true}},
+	})
+	if report.Score != 0 || report.Level != LevelNone {
+		t.Errorf("Analyze() = %s, expected no risk", report)
+	}
+}
+
+func TestAnalyzeRiskyWarp(t *testing.T) {
+	warp := warptest.FixtureWarp()
+	brand := warptest.FixtureBrand()
+
+	collect := types.WarpCollectAction{Type: types.CollectActionType, Label: "Sign up"}
+	collect.Destination.URL = "http://collector.io/submit"
+	warp.Actions = append(warp.Actions,
+		collect,
+		types.WarpLinkAction{Type: types.LinkActionType, Label: "Docs", URL: "https://exarnple.com/docs"},
+		types.WarpTransferAction{
+			Type:   types.TransferActionType,
+			Label:  "Send",
+			Inputs: []types.WarpActionInput{{Name: "amount", Type: "biguint", Position: types.ValuePosition, Source: types.FieldSource}},
+		},
+	)
+
+	analyzer := NewWarpAnalyzer(types.WarpConfig{})
+	report := analyzer.Analyze(Subject{
+		Warp:         &warp,
+		RegistryInfo: &types.RegistryInfo{Trust: types.Unverified},
+		Brand:        &brand,
+		Contracts:    map[string]*types.WarpContract{warptest.RegistryContract: {Address: warptest.RegistryContract}},
+	})
+
+	codes := make(map[string]string)
+	for _, finding := range report.Findings {
+		codes[finding.Code] = finding.Path
+	}
+	expected := map[string]string{
+		CodeUnverifiedTrust:    "",
+		CodeUnverifiedContract: "/actions/0/address",
+		CodeInsecureURL:        "/actions/1/destination/url",
+		CodeOffBrandDomain:     "/actions/1/destination/url",
+		CodeLookalikeDomain:    "/actions/2/url",
+		CodeUnboundedAmount:    "/actions/3/inputs/0",
+	}
+	for code, path := range expected {
+		if got, found := codes[code]; !found || got != path {
+			t.Errorf("finding %s at %q, expected at %q\n%s", code, got, path, report)
+		}
+	}
+	if report.Score != 100 || report.Level != LevelCritical {
+		t.Errorf("Analyze() score = %d (%s), expected the capped maximum", report.Score, report.Level)
+	}
+	if report.Findings[0].Severity != SeverityHigh || !strings.Contains(report.String(), "exarnple.com looks like example.com") {
+		t.Errorf("Analyze() = %s, expected the most severe findings first and explained", report)
+	}
+
+	blacklisted := analyzer.Analyze(Subject{RegistryInfo: &types.RegistryInfo{Trust: types.Blacklisted}})
+	if blacklisted.Level != LevelCritical {
+		t.Errorf("Analyze() level = %s, expected a blacklisted warp to be critical", blacklisted.Level)
+	}
+}
+
+func TestAnalyzeNonWebURLs(t *testing.T) {
+	analyzer := NewWarpAnalyzer(types.WarpConfig{})
+	verified := &types.RegistryInfo{Trust: types.Verified}
+
+	cases := []struct {
+		url      string
+		code     string
+		severity Severity
+	}{
+		{"javascript:alert(document.cookie)", CodeUnsafeScheme, SeverityCritical},
+		{"JavaScript:alert(1)", CodeUnsafeScheme, SeverityCritical},
+		{"data:text/html;base64,PHNjcmlwdD4=", CodeUnsafeScheme, SeverityCritical},
+		{"ftp://files.example.com/app", CodeUnsafeScheme, SeverityHigh},
+		{"//example.com/docs", CodeUnsafeScheme, SeverityHigh},
+		{"https:///docs", CodeInvalidURL, SeverityHigh},
+		{"http://[::1", CodeInvalidURL, SeverityHigh},
+	}
+	for _, c := range cases {
+		collect := types.WarpCollectAction{Type: types.CollectActionType, Label: "Sign up"}
+		collect.Destination.URL = c.url
+		link := types.WarpLinkAction{Type: types.LinkActionType, Label: "Docs", URL: c.url}
+
+		for _, action := range []types.WarpAction{collect, link} {
+			warp := types.Warp{Actions: []types.WarpAction{action}}
+			report := analyzer.Analyze(Subject{Warp: &warp, RegistryInfo: verified})
+			if len(report.Findings) != 1 || report.Findings[0].Code != c.code || report.Findings[0].Severity != c.severity {
+				t.Errorf("Analyze(%s %q) = %s, expected a %s %s finding", action.GetType(), c.url, report, c.severity, c.code)
+			}
+		}
+	}
+}
+
+func TestLookalikeOf(t *testing.T) {
+	known := []string{"multiversx.com", "example.com"}
+	for domain, expected := range map[string]string{
+		"multiversx.com":  "",
+		"rnultiversx.com": "multiversx.com",
+		"mu1tiversx.com":  "multiversx.com",
+		"examples.com":    "example.com",
+		"unrelated.org":   "",
+	} {
+		if got := lookalikeOf(domain, known); got != expected {
+			t.Errorf("lookalikeOf(%s) = %q, expected %q", domain, got, expected)
+		}
+	}
+}
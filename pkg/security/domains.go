@@ -0,0 +1,61 @@
+package security
+
+import (
+	"strings"
+)
+
+// registrableDomain returns the last two labels of a host name, e.g. "example.com" for "app.example.com".
+// Multi-label public suffixes such as "co.uk" are not special-cased.
+func registrableDomain(host string) string {
+	labels := strings.Split(strings.TrimSuffix(strings.ToLower(host), "."), ".")
+	if len(labels) <= 2 {
+		return strings.Join(labels, ".")
+	}
+	return strings.Join(labels[len(labels)-2:], ".")
+}
+
+// homoglyphs maps characters and sequences commonly swapped in phishing domains to the ones they imitate
+var homoglyphs = strings.NewReplacer("rn", "m", "vv", "w", "0", "o", "1", "l", "3", "e", "5", "s", "-", "")
+
+// lookalikeOf returns the known domain that a domain imitates, or "" if it imitates none.
+// A domain imitates another if they differ but read the same once homoglyphs are replaced,
+// or are at most two edits apart.
+func lookalikeOf(domain string, known []string) string {
+	for _, candidate := range known {
+		if domain == candidate {
+			return ""
+		}
+	}
+
+	for _, candidate := range known {
+		if homoglyphs.Replace(domain) == homoglyphs.Replace(candidate) {
+			return candidate
+		}
+		if len(candidate) > 5 && editDistance(domain, candidate) <= 2 {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,162 @@
+package types
+
+// AbiMutability represents whether an endpoint may change the contract state
+type AbiMutability string
+
+// ABI endpoint mutabilities
+const (
+	AbiMutable  AbiMutability = "mutable"
+	AbiReadonly AbiMutability = "readonly"
+)
+
+// AbiTypeDefKind represents the kind of a custom ABI type
+type AbiTypeDefKind string
+
+// ABI custom type kinds
+const (
+	AbiStruct       AbiTypeDefKind = "struct"
+	AbiEnum         AbiTypeDefKind = "enum"
+	AbiExplicitEnum AbiTypeDefKind = "explicit-enum"
+)
+
+// AbiAnyToken is the payable token wildcard accepting any token
+const AbiAnyToken = "*"
+
+// AbiContents represents ABI contents, in the MultiversX ABI JSON format
+type AbiContents struct {
+	Name               *string               `json:"name,omitempty"`
+	Constructor        *AbiConstructor       `json:"constructor,omitempty"`
+	UpgradeConstructor *AbiConstructor       `json:"upgradeConstructor,omitempty"`
+	Endpoints          []AbiEndpoint         `json:"endpoints,omitempty"`
+	Types              map[string]AbiTypeDef `json:"types,omitempty"`
+	Events             []AbiEvent            `json:"events,omitempty"`
+}
+
+// AbiConstructor represents the constructor or upgrade constructor of a contract
+type AbiConstructor struct {
+	Docs    []string    `json:"docs,omitempty"`
+	Inputs  []AbiInput  `json:"inputs"`
+	Outputs []AbiOutput `json:"outputs"`
+}
+
+// AbiEndpoint represents a contract endpoint
+type AbiEndpoint struct {
+	Docs            []string      `json:"docs,omitempty"`
+	Name            string        `json:"name"`
+	OnlyOwner       bool          `json:"onlyOwner,omitempty"`
+	Mutability      AbiMutability `json:"mutability"`
+	PayableInTokens []string      `json:"payableInTokens,omitempty"`
+	Inputs          []AbiInput    `json:"inputs"`
+	Outputs         []AbiOutput   `json:"outputs"`
+}
+
+// AbiInput represents an endpoint or constructor parameter
+type AbiInput struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	MultiArg bool   `json:"multi_arg,omitempty"`
+}
+
+// AbiOutput represents an endpoint or constructor result
+type AbiOutput struct {
+	Name        string `json:"name,omitempty"`
+	Type        string `json:"type"`
+	MultiResult bool   `json:"multi_result,omitempty"`
+}
+
+// AbiTypeDef represents a custom type, a struct or an enum
+type AbiTypeDef struct {
+	Type     AbiTypeDefKind   `json:"type"`
+	Docs     []string         `json:"docs,omitempty"`
+	Fields   []AbiField       `json:"fields,omitempty"`
+	Variants []AbiEnumVariant `json:"variants,omitempty"`
+}
+
+// AbiField represents a field of a struct or of an enum variant
+type AbiField struct {
+	Name string   `json:"name"`
+	Type string   `json:"type"`
+	Docs []string `json:"docs,omitempty"`
+}
+
+// AbiEnumVariant represents a variant of an enum
+type AbiEnumVariant struct {
+	Name         string     `json:"name"`
+	Discriminant int        `json:"discriminant"`
+	Fields       []AbiField `json:"fields,omitempty"`
+	Docs         []string   `json:"docs,omitempty"`
+}
+
+// AbiEvent represents an event emitted by the contract
+type AbiEvent struct {
+	Identifier string          `json:"identifier"`
+	Docs       []string        `json:"docs,omitempty"`
+	Inputs     []AbiEventInput `json:"inputs"`
+}
+
+// AbiEventInput represents a field of an event. Indexed fields are emitted as topics, the others as data.
+type AbiEventInput struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Indexed bool   `json:"indexed,omitempty"`
+}
+
+// Endpoint returns the endpoint with the given name
+func (c *AbiContents) Endpoint(name string) (*AbiEndpoint, bool) {
+	for i := range c.Endpoints {
+		if c.Endpoints[i].Name == name {
+			return &c.Endpoints[i], true
+		}
+	}
+	return nil, false
+}
+
+// TypeDef returns the custom type with the given name
+func (c *AbiContents) TypeDef(name string) (*AbiTypeDef, bool) {
+	typeDef, found := c.Types[name]
+	if !found {
+		return nil, false
+	}
+	return &typeDef, true
+}
+
+// Event returns the event with the given identifier
+func (c *AbiContents) Event(identifier string) (*AbiEvent, bool) {
+	for i := range c.Events {
+		if c.Events[i].Identifier == identifier {
+			return &c.Events[i], true
+		}
+	}
+	return nil, false
+}
+
+// IsReadonly returns whether the endpoint is a view that cannot change the contract state
+func (e *AbiEndpoint) IsReadonly() bool {
+	return e.Mutability == AbiReadonly
+}
+
+// IsPayable returns whether the endpoint accepts any payment
+func (e *AbiEndpoint) IsPayable() bool {
+	return len(e.PayableInTokens) > 0
+}
+
+// AcceptsToken returns whether the endpoint accepts payments in the token
+func (e *AbiEndpoint) AcceptsToken(token string) bool {
+	for _, accepted := range e.PayableInTokens {
+		if accepted == AbiAnyToken || accepted == token {
+			return true
+		}
+	}
+	return false
+}
+
+// IndexedInputs returns the event fields emitted as topics, in order
+func (e *AbiEvent) IndexedInputs() []AbiEventInput {
+	var inputs []AbiEventInput
+	for _, input := range e.Inputs {
+		if input.Indexed {
+			inputs = append(inputs, input)
+		}
+	}
+	return inputs
+}
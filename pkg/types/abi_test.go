@@ -0,0 +1,118 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// testAbi is a contract ABI as generated by the MultiversX framework
+const testAbi = `{
+	"buildInfo": {"framework": {"name": "multiversx-sc", "version": "0.47.0"}},
+	"name": "Lottery",
+	"constructor": {"inputs": [{"name": "fee", "type": "BigUint"}], "outputs": []},
+	"endpoints": [
+		{
+			"name": "buyTicket",
+			"mutability": "mutable",
+			"payableInTokens": ["*"],
+			"inputs": [{"name": "lottery_name", "type": "bytes"}],
+			"outputs": []
+		},
+		{
+			"docs": ["Returns the lottery status"],
+			"name": "status",
+			"mutability": "readonly",
+			"inputs": [{"name": "lottery_name", "type": "bytes"}],
+			"outputs": [{"type": "Status"}]
+		},
+		{
+			"name": "getLotteryInfo",
+			"mutability": "readonly",
+			"inputs": [{"name": "names", "type": "variadic<bytes>", "multi_arg": true}],
+			"outputs": [{"type": "variadic<LotteryInfo>", "multi_result": true}]
+		}
+	],
+	"events": [
+		{
+			"identifier": "ticketBought",
+			"inputs": [
+				{"name": "buyer", "type": "Address", "indexed": true},
+				{"name": "lottery_name", "type": "bytes", "indexed": true},
+				{"name": "amount", "type": "BigUint"}
+			]
+		}
+	],
+	"hasCallback": false,
+	"types": {
+		"LotteryInfo": {
+			"type": "struct",
+			"fields": [
+				{"name": "token_identifier", "type": "EgldOrEsdtTokenIdentifier"},
+				{"name": "deadline", "type": "u64"},
+				{"name": "winners", "type": "List<Address>"}
+			]
+		},
+		"Status": {
+			"type": "enum",
+			"variants": [
+				{"name": "Inactive", "discriminant": 0},
+				{"name": "Running", "discriminant": 1},
+				{"name": "Ended", "discriminant": 2}
+			]
+		}
+	}
+}`
+
+func TestAbiContentsParse(t *testing.T) {
+	var abi AbiContents
+	if err := json.Unmarshal([]byte(testAbi), &abi); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if abi.Constructor == nil || len(abi.Constructor.Inputs) != 1 || abi.Constructor.Inputs[0].Type != "BigUint" {
+		t.Errorf("Constructor = %v, expected a single BigUint input", abi.Constructor)
+	}
+
+	buyTicket, found := abi.Endpoint("buyTicket")
+	if !found {
+		t.Fatal("Endpoint(buyTicket) not found")
+	}
+	if buyTicket.IsReadonly() || !buyTicket.IsPayable() || !buyTicket.AcceptsToken("USDC-c76f1f") {
+		t.Errorf("buyTicket = %+v, expected a mutable endpoint payable in any token", buyTicket)
+	}
+
+	status, _ := abi.Endpoint("status")
+	if !status.IsReadonly() || status.IsPayable() || status.AcceptsToken("EGLD") {
+		t.Errorf("status = %+v, expected a non-payable view", status)
+	}
+	if len(status.Docs) != 1 {
+		t.Errorf("status.Docs = %v, expected one line", status.Docs)
+	}
+
+	info, _ := abi.Endpoint("getLotteryInfo")
+	if !info.Inputs[0].MultiArg || !info.Outputs[0].MultiResult {
+		t.Errorf("getLotteryInfo = %+v, expected a multi arg input and a multi result output", info)
+	}
+
+	if _, found := abi.Endpoint("unknown"); found {
+		t.Error("Endpoint(unknown) found, expected none")
+	}
+
+	lotteryInfo, found := abi.TypeDef("LotteryInfo")
+	if !found || lotteryInfo.Type != AbiStruct || len(lotteryInfo.Fields) != 3 {
+		t.Errorf("TypeDef(LotteryInfo) = %+v, expected a struct with 3 fields", lotteryInfo)
+	}
+
+	statusType, found := abi.TypeDef("Status")
+	if !found || statusType.Type != AbiEnum || statusType.Variants[2].Name != "Ended" || statusType.Variants[2].Discriminant != 2 {
+		t.Errorf("TypeDef(Status) = %+v, expected an enum with 3 variants", statusType)
+	}
+
+	event, found := abi.Event("ticketBought")
+	if !found {
+		t.Fatal("Event(ticketBought) not found")
+	}
+	if indexed := event.IndexedInputs(); len(indexed) != 2 || indexed[1].Name != "lottery_name" {
+		t.Errorf("IndexedInputs() = %v, expected buyer and lottery_name", indexed)
+	}
+}
@@ -1,8 +1,11 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
-	"time"
+	"net/http"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
 )
 
 // ChainEnv represents the blockchain environment
@@ -38,6 +41,13 @@ type WarpConfig struct {
 	IndexAPIKey          string            `json:"indexApiKey,omitempty"`
 	IndexSearchParamName string            `json:"indexSearchParamName,omitempty"`
 	Vars                 map[string]string `json:"vars,omitempty"`
+	AllowedEnvVars       []string          `json:"allowedEnvVars,omitempty"` // environment variables warps may read with "env:NAME" vars
+
+	// Observer receives logs, spans and metrics; a no-op observer is used when nil
+	Observer observability.Observer `json:"-"`
+
+	// HTTPTransport is used for all HTTP calls made by the SDK; http.DefaultTransport is used when nil
+	HTTPTransport http.RoundTripper `json:"-"`
 }
 
 // WarpCacheConfig represents cache configuration
@@ -90,6 +100,65 @@ type Warp struct {
 	Meta        *WarpMeta                    `json:"meta,omitempty"`
 }
 
+// UnmarshalJSON decodes a warp, resolving each action to its concrete type
+func (w *Warp) UnmarshalJSON(data []byte) error {
+	type warpAlias Warp
+	var raw struct {
+		warpAlias
+		Actions []json.RawMessage `json:"actions"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*w = Warp(raw.warpAlias)
+	w.Actions = make([]WarpAction, 0, len(raw.Actions))
+	for i, rawAction := range raw.Actions {
+		action, err := UnmarshalWarpAction(rawAction)
+		if err != nil {
+			return fmt.Errorf("invalid action at index %d: %w", i, err)
+		}
+		w.Actions = append(w.Actions, action)
+	}
+
+	return nil
+}
+
+// UnmarshalWarpAction decodes an action into the concrete type matching its type field
+func UnmarshalWarpAction(data []byte) (WarpAction, error) {
+	var header struct {
+		Type WarpActionType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	switch header.Type {
+	case TransferActionType:
+		var action WarpTransferAction
+		err := json.Unmarshal(data, &action)
+		return action, err
+	case ContractActionType:
+		var action WarpContractAction
+		err := json.Unmarshal(data, &action)
+		return action, err
+	case QueryActionType:
+		var action WarpQueryAction
+		err := json.Unmarshal(data, &action)
+		return action, err
+	case CollectActionType:
+		var action WarpCollectAction
+		err := json.Unmarshal(data, &action)
+		return action, err
+	case LinkActionType:
+		var action WarpLinkAction
+		err := json.Unmarshal(data, &action)
+		return action, err
+	default:
+		return nil, fmt.Errorf("unsupported action type: %s", header.Type)
+	}
+}
+
 // WarpMeta represents metadata about a warp
 type WarpMeta struct {
 	Hash      string `json:"hash"`
@@ -160,6 +229,7 @@ type WarpContractAction struct {
 	Value       *string                     `json:"value,omitempty"`
 	GasLimit    int                         `json:"gasLimit"`
 	Transfers   []WarpContractActionTransfer `json:"transfers,omitempty"`
+	ABI         *string                     `json:"abi,omitempty"`
 	Inputs      []WarpActionInput           `json:"inputs,omitempty"`
 	Next        *string                     `json:"next,omitempty"`
 }
@@ -374,17 +444,33 @@ type WarpActionExecutionResult struct {
 	Tx *string `json:"tx,omitempty"`
 }
 
+// Transaction represents an unsigned transaction, ready for signing and broadcast.
+// The nonce is left for the signer to set.
+type Transaction struct {
+	Nonce    uint64 `json:"nonce"`
+	Value    string `json:"value"`
+	Receiver string `json:"receiver"`
+	Sender   string `json:"sender"`
+	GasPrice uint64 `json:"gasPrice"`
+	GasLimit uint64 `json:"gasLimit"`
+	Data     []byte `json:"data,omitempty"` // base64 encoded in JSON
+	ChainID  string `json:"chainID"`
+	Version  uint32 `json:"version"`
+}
+
 // WarpContract represents a smart contract
 type WarpContract struct {
-	Address  string `json:"address"`
-	Owner    string `json:"owner"`
-	Verified bool   `json:"verified"`
+	Address  string       `json:"address"`
+	Owner    string       `json:"owner"`
+	CodeHash string       `json:"codeHash,omitempty"`
+	Verified bool         `json:"verified"`
+	ABI      *AbiContents `json:"abi,omitempty"` // ABI of the verified source code, only set in detection results
 }
 
 // WarpContractVerification represents contract verification info
 type WarpContractVerification struct {
-	CodeHash string      `json:"codeHash"`
-	ABI      interface{} `json:"abi"`
+	CodeHash string       `json:"codeHash"`
+	ABI      *AbiContents `json:"abi"` // ABI of the verified source code
 }
 
 // Brand represents brand information
@@ -450,23 +536,19 @@ type WarpAbi struct {
 	Meta     *WarpMeta    `json:"meta,omitempty"`
 }
 
-// AbiContents represents ABI contents
-type AbiContents struct {
-	Name               *string           `json:"name,omitempty"`
-	Constructor        interface{}       `json:"constructor,omitempty"`
-	UpgradeConstructor interface{}       `json:"upgradeConstructor,omitempty"`
-	Endpoints          []interface{}     `json:"endpoints,omitempty"`
-	Types              map[string]interface{} `json:"types,omitempty"`
-	Events             []interface{}     `json:"events,omitempty"`
-}
-
 // DetectionResult represents the result of a warp detection
 type DetectionResult struct {
-	Match        bool         `json:"match"`
-	URL          string       `json:"url"`
-	Warp         *Warp        `json:"warp"`
-	RegistryInfo *RegistryInfo `json:"registryInfo"`
-	Brand        *Brand       `json:"brand"`
+	Match         bool          `json:"match"`
+	URL           string        `json:"url"`
+	Warp          *Warp         `json:"warp"`
+	RegistryInfo  *RegistryInfo `json:"registryInfo"`
+	Brand         *Brand        `json:"brand"`
+	RequestedHash string        `json:"requestedHash,omitempty"` // hash referenced by the URL
+	ResolvedHash  string        `json:"resolvedHash,omitempty"`  // hash of the returned warp
+
+	// Contracts targeted by the warp's actions, keyed by address, nil for contracts that could not be
+	// fetched. Only set with link.DetectOptions.IncludeContracts.
+	Contracts map[string]*WarpContract `json:"contracts,omitempty"`
 }
 
 // DetectionResultFromHTML represents the result of detecting warps in HTML content
@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// actionTypes maps each action implementation to its type, for actions built without a type
+var actionTypes = map[string]types.WarpActionType{
+	fmt.Sprintf("%T", types.WarpTransferAction{}): types.TransferActionType,
+	fmt.Sprintf("%T", types.WarpContractAction{}): types.ContractActionType,
+	fmt.Sprintf("%T", types.WarpQueryAction{}):    types.QueryActionType,
+	fmt.Sprintf("%T", types.WarpCollectAction{}):  types.CollectActionType,
+	fmt.Sprintf("%T", types.WarpLinkAction{}):     types.LinkActionType,
+}
+
+// CanonicalJSON returns the canonical JSON encoding of a warp, without its meta. Object keys are sorted,
+// null values, empty strings, empty arrays and empty objects are omitted from objects, numbers are
+// written in their shortest form, actions always carry their type and HTML characters are not escaped.
+// Two warps with the same content have the same canonical encoding, whether built or decoded.
+func CanonicalJSON(warp *types.Warp) ([]byte, error) {
+	if warp == nil {
+		return nil, fmt.Errorf("canonical JSON: warp is nil")
+	}
+
+	content := *warp
+	content.Meta = nil
+
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("canonical JSON: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var document map[string]interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("canonical JSON: %w", err)
+	}
+
+	if actions, ok := document["actions"].([]interface{}); ok {
+		for i, action := range actions {
+			if fields, ok := action.(map[string]interface{}); ok && fields["type"] == "" {
+				fields["type"] = string(actionTypes[fmt.Sprintf("%T", warp.Actions[i])])
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(normalizeJSON(document)); err != nil {
+		return nil, fmt.Errorf("canonical JSON: %w", err)
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// ContentHash returns the hex encoded SHA-256 hash of the canonical JSON encoding of a warp.
+// It identifies the content of a warp independently of its inscription, see CanonicalJSON.
+func ContentHash(warp *types.Warp) (string, error) {
+	canonical, err := CanonicalJSON(warp)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SameContent reports whether two warps have the same content, ignoring their meta
+func SameContent(a *types.Warp, b *types.Warp) (bool, error) {
+	canonicalA, err := CanonicalJSON(a)
+	if err != nil {
+		return false, err
+	}
+	canonicalB, err := CanonicalJSON(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(canonicalA, canonicalB), nil
+}
+
+// normalizeJSON omits the empty values of objects and rewrites numbers in their shortest form
+func normalizeJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			field = normalizeJSON(field)
+			if !isEmptyJSON(field) {
+				result[key] = field
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = normalizeJSON(item)
+		}
+		return result
+	case json.Number:
+		return normalizeNumber(v)
+	}
+	return value
+}
+
+// isEmptyJSON reports whether a value is null, an empty string, an empty array or an empty object
+func isEmptyJSON(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// normalizeNumber writes integers without exponent or fraction, e.g. 1e3 and 1000.0 as 1000
+func normalizeNumber(number json.Number) json.Number {
+	if integer, ok := new(big.Int).SetString(number.String(), 10); ok {
+		return json.Number(integer.String())
+	}
+
+	float, _, err := big.ParseFloat(number.String(), 10, 256, big.ToNearestEven)
+	if err != nil {
+		return number
+	}
+	if float.IsInt() {
+		integer, _ := float.Int(nil)
+		return json.Number(integer.String())
+	}
+
+	f, _ := float.Float64()
+	return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+}
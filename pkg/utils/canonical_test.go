@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	fn := "stake"
+	empty := ""
+	warp := &types.Warp{
+		Protocol:    "warp-0.0.2",
+		Name:        "stake",
+		Title:       "Stake <now>",
+		Description: &empty,
+		Vars:        map[types.WarpVarPlaceholder]string{},
+		Actions: []types.WarpAction{
+			types.WarpContractAction{Label: "Stake", Address: "erd1qqq", Func: &fn, Args: []string{}, GasLimit: 5000000},
+			types.WarpLinkAction{Type: types.LinkActionType, Label: "Docs", URL: "https://example.com?a=1&b=2"},
+		},
+		Meta: &types.WarpMeta{Hash: "abc", Creator: "erd1creator"},
+	}
+
+	canonical, err := CanonicalJSON(warp)
+	if err != nil {
+		t.Fatalf("CanonicalJSON() error = %v", err)
+	}
+	expected := `{"actions":[{"address":"erd1qqq","func":"stake","gasLimit":5000000,"label":"Stake","type":"contract"},` +
+		`{"label":"Docs","type":"link","url":"https://example.com?a=1&b=2"}],"name":"stake","protocol":"warp-0.0.2","title":"Stake <now>"}`
+	if string(canonical) != expected {
+		t.Errorf("CanonicalJSON() = %s, expected %s", canonical, expected)
+	}
+
+	// The decoded warp, with other empty fields and meta, has the same content
+	var decoded types.Warp
+	if err := json.Unmarshal([]byte(`{"protocol":"warp-0.0.2","name":"stake","title":"Stake <now>","description":null,`+
+		`"actions":[{"type":"contract","label":"Stake","address":"erd1qqq","func":"stake","args":null,"gasLimit":5000000},`+
+		`{"type":"link","label":"Docs","url":"https://example.com?a=1&b=2","inputs":[]}]}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	same, err := SameContent(warp, &decoded)
+	if err != nil || !same {
+		decodedCanonical, _ := CanonicalJSON(&decoded)
+		t.Errorf("SameContent() = %v, %v, expected %s to match", same, err, decodedCanonical)
+	}
+
+	hash, err := ContentHash(warp)
+	if err != nil || len(hash) != 64 {
+		t.Fatalf("ContentHash() = %s, %v", hash, err)
+	}
+	decodedHash, _ := ContentHash(&decoded)
+	if hash != decodedHash {
+		t.Errorf("ContentHash() = %s and %s, expected equal hashes", hash, decodedHash)
+	}
+
+	decoded.Title = "Stake later"
+	if same, _ := SameContent(warp, &decoded); same {
+		t.Error("SameContent() = true, expected different titles to differ")
+	}
+	if changedHash, _ := ContentHash(&decoded); changedHash == hash {
+		t.Error("ContentHash() unchanged, expected a different title to change the hash")
+	}
+
+	if _, err := CanonicalJSON(nil); err == nil {
+		t.Error("CanonicalJSON(nil) error = nil, expected an error")
+	}
+}
+
+func TestNormalizeNumber(t *testing.T) {
+	tests := map[string]string{"1000": "1000", "1e3": "1000", "1000.0": "1000", "0.5": "0.5", "-2.50": "-2.5", "18446744073709551616": "18446744073709551616"}
+	for number, expected := range tests {
+		if result := normalizeNumber(json.Number(number)); string(result) != expected {
+			t.Errorf("normalizeNumber(%s) = %s, expected %s", number, result, expected)
+		}
+	}
+}
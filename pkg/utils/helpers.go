@@ -2,11 +2,14 @@ package utils
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/protocol"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
 )
 
@@ -24,9 +27,21 @@ func GetChainID(env types.ChainEnv) string {
 	}
 }
 
+// NewHTTPClient creates the HTTP client used by the SDK components for the specified configuration
+func NewHTTPClient(config types.WarpConfig) *http.Client {
+	transport := config.HTTPTransport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &http.Client{
+		Transport: observability.NewTransport(config.Observer, transport),
+	}
+}
+
 // GetLatestProtocolIdentifier returns the latest protocol identifier for the specified protocol
-func GetLatestProtocolIdentifier(protocol types.ProtocolName) string {
-	return fmt.Sprintf("%s-0.0.2", protocol)
+func GetLatestProtocolIdentifier(protocolName types.ProtocolName) string {
+	return protocol.Latest(protocolName).String()
 }
 
 // ToPreviewText converts a string to a preview text limited to the specified number of characters
@@ -39,7 +54,16 @@ func ToPreviewText(text string, maxChars int) string {
 		return text
 	}
 
-	return fmt.Sprintf("%s...", text[:maxChars-3])
+	// Cut on a word boundary so the preview doesn't end mid-word
+	cut := text[:maxChars-3]
+	if text[maxChars-3] != ' ' {
+		if idx := strings.LastIndex(cut, " "); idx > 0 {
+			cut = cut[:idx]
+		}
+	}
+	cut = strings.TrimRight(cut, " ,.;:-")
+
+	return fmt.Sprintf("%s...", cut)
 }
 
 // GetInfoFromPrefixedIdentifier extracts the identifier type and ID from a prefixed identifier
@@ -69,7 +93,7 @@ func GetInfoFromPrefixedIdentifier(identifier string) *struct {
 	}
 
 	// If no prefix, assume it's a transaction hash
-	hashPattern := regexp.MustCompile(`^[a-f0-9]{64}$`)
+	hashPattern := regexp.MustCompile(`^[a-f0-9]{32,}$`)
 	if hashPattern.MatchString(identifier) {
 		return &struct {
 			Type types.WarpIDType
@@ -104,22 +128,3 @@ func FormatTimeISO8601(t time.Time) string {
 func ParseTimeISO8601(s string) (time.Time, error) {
 	return time.Parse(time.RFC3339, s)
 }
-
-// PrepareVars replaces variable placeholders in a warp with values from the config
-func PrepareVars(warp *types.Warp, config types.WarpConfig) *types.Warp {
-	if warp == nil || warp.Vars == nil || config.Vars == nil {
-		return warp
-	}
-
-	// Deep copy the warp to avoid modifying the original
-	result := *warp
-
-	// Apply the variables
-	for key, value := range warp.Vars {
-		if configValue, exists := config.Vars[string(key)]; exists {
-			warp.Vars[key] = configValue
-		}
-	}
-
-	return &result
-} 
\ No newline at end of file
@@ -140,4 +140,35 @@ func TestGetInfoFromPrefixedIdentifier(t *testing.T) {
 			}
 		})
 	}
-} 
\ No newline at end of file
+} 
+
+func TestToPreviewTextWordBoundary(t *testing.T) {
+	tests := []struct {
+		text     string
+		maxChars int
+		expected string
+	}{
+		{"Stake your EGLD with us", 14, "Stake your..."},
+		{"Stake your EGLD with us", 13, "Stake your..."},
+		{"Stake, then claim", 10, "Stake..."},
+		{"Supercalifragilistic", 10, "Superca..."},
+	}
+
+	for _, tt := range tests {
+		if result := ToPreviewText(tt.text, tt.maxChars); result != tt.expected {
+			t.Errorf("ToPreviewText(%s, %d) = %s, expected %s", tt.text, tt.maxChars, result, tt.expected)
+		}
+	}
+}
+
+func TestGetInfoFromPrefixedIdentifierHashLength(t *testing.T) {
+	hash := "5d4b1c8e3f2a90b7c6d5e4f3a2b1c0d9"
+	if result := GetInfoFromPrefixedIdentifier(hash); result == nil || result.Type != types.HashIDType {
+		t.Errorf("GetInfoFromPrefixedIdentifier(%s) = %v, expected a 32 character hash", hash, result)
+	}
+
+	short := hash[:31]
+	if result := GetInfoFromPrefixedIdentifier(short); result == nil || result.Type != types.AliasIDType {
+		t.Errorf("GetInfoFromPrefixedIdentifier(%s) = %v, expected shorter hex to be an alias", short, result)
+	}
+}
@@ -0,0 +1,303 @@
+package utils
+
+import (
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// VarPlaceholderPattern matches variable placeholders such as {{AMOUNT}}, capturing the variable name
+var VarPlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// Var sources, used in warp var definitions as "{source}:{name}"
+const (
+	VarSourceQuery = "query" // "query:{param}", the parameter of the query string of WarpConfig.CurrentURL
+	VarSourceEnv   = "env"   // "env:{NAME}", the environment variable if listed in WarpConfig.AllowedEnvVars
+	VarSourceUser  = "user"  // "user:wallet", WarpConfig.UserAddress
+)
+
+// PrepareVars returns a copy of a warp with its variable placeholders replaced, leaving unresolved
+// placeholders in place. See ApplyVars.
+func PrepareVars(warp *types.Warp, config types.WarpConfig) *types.Warp {
+	prepared, _ := ApplyVars(warp, config, nil)
+	return prepared
+}
+
+// ApplyVars returns a deep copy of a warp with the {{VAR}} placeholders of its string fields replaced:
+// titles, descriptions and labels, action addresses, args, values, transfers, URLs, headers, next
+// references and input Min/Max. Variables take their values as described by ResolveVars.
+// It also returns the sorted names of the placeholders left unresolved. The original warp is not modified.
+func ApplyVars(warp *types.Warp, config types.WarpConfig, overrides map[string]string) (*types.Warp, []string) {
+	if warp == nil {
+		return nil, nil
+	}
+
+	t := &templater{values: ResolveVars(warp, config, overrides), unresolved: make(map[string]bool)}
+	result := t.warp(warp)
+
+	unresolved := make([]string, 0, len(t.unresolved))
+	for name := range t.unresolved {
+		unresolved = append(unresolved, name)
+	}
+	sort.Strings(unresolved)
+
+	return result, unresolved
+}
+
+// ResolveVars returns the values of a warp's variables. A variable takes the first value found, in order:
+//  1. the per-call overrides
+//  2. WarpConfig.Vars
+//  3. the source of the warp's var definition: "query:{param}", "env:{NAME}" or "user:wallet".
+//     A variable whose source has no value, e.g. a missing query parameter or an environment
+//     variable that is not allowed, is left unresolved.
+//  4. the warp's var definition itself, as a literal value, if it has no source
+func ResolveVars(warp *types.Warp, config types.WarpConfig, overrides map[string]string) map[string]string {
+	values := make(map[string]string)
+	if warp != nil {
+		for name, definition := range warp.Vars {
+			if value, found := resolveVarSource(definition, config); found {
+				values[string(name)] = value
+			}
+		}
+	}
+	for name, value := range config.Vars {
+		values[name] = value
+	}
+	for name, value := range overrides {
+		values[name] = value
+	}
+	return values
+}
+
+// resolveVarSource resolves a var definition, returning false if its source has no value
+func resolveVarSource(definition string, config types.WarpConfig) (string, bool) {
+	source, name, found := strings.Cut(definition, constants.WarpConstants.ArgParamsSeparator)
+	if !found {
+		return definition, true
+	}
+
+	switch source {
+	case VarSourceQuery:
+		currentURL, err := url.Parse(config.CurrentURL)
+		if err != nil || !currentURL.Query().Has(name) {
+			return "", false
+		}
+		return currentURL.Query().Get(name), true
+	case VarSourceEnv:
+		for _, allowed := range config.AllowedEnvVars {
+			if allowed == name {
+				return os.LookupEnv(name)
+			}
+		}
+		return "", false
+	case VarSourceUser:
+		if name != "wallet" || config.UserAddress == "" {
+			return "", false
+		}
+		return config.UserAddress, true
+	}
+	return definition, true
+}
+
+// templater copies warps, replacing placeholders with the variable values
+type templater struct {
+	values     map[string]string
+	unresolved map[string]bool
+}
+
+// str replaces the placeholders of a string
+func (t *templater) str(s string) string {
+	if !strings.Contains(s, "{{") {
+		return s
+	}
+	return VarPlaceholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := VarPlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		value, found := t.values[name]
+		if !found {
+			t.unresolved[name] = true
+			return placeholder
+		}
+		return value
+	})
+}
+
+// strPtr copies a string pointer, replacing its placeholders
+func (t *templater) strPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	value := t.str(*s)
+	return &value
+}
+
+// strs copies a string slice, replacing placeholders
+func (t *templater) strs(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = t.str(value)
+	}
+	return result
+}
+
+// bound copies an input Min or Max. Placeholders that resolve to numbers become numbers.
+func (t *templater) bound(value interface{}) interface{} {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	resolved := t.str(s)
+	if number, err := strconv.ParseFloat(resolved, 64); err == nil && resolved != s {
+		return number
+	}
+	return resolved
+}
+
+// warp copies a warp, replacing placeholders
+func (t *templater) warp(warp *types.Warp) *types.Warp {
+	result := *warp
+	result.Title = t.str(warp.Title)
+	result.Description = t.strPtr(warp.Description)
+	result.Preview = t.strPtr(warp.Preview)
+	result.Bot = copyStr(warp.Bot)
+	result.Next = t.strPtr(warp.Next)
+
+	if warp.Vars != nil {
+		result.Vars = make(map[types.WarpVarPlaceholder]string, len(warp.Vars))
+		for name, value := range warp.Vars {
+			result.Vars[name] = value
+		}
+	}
+	if warp.Meta != nil {
+		meta := *warp.Meta
+		result.Meta = &meta
+	}
+	if warp.Actions != nil {
+		result.Actions = make([]types.WarpAction, len(warp.Actions))
+		for i, action := range warp.Actions {
+			result.Actions[i] = t.action(action)
+		}
+	}
+
+	return &result
+}
+
+// action copies an action, replacing placeholders
+func (t *templater) action(action types.WarpAction) types.WarpAction {
+	switch a := action.(type) {
+	case types.WarpTransferAction:
+		a.Label = t.str(a.Label)
+		a.Description = t.strPtr(a.Description)
+		a.Address = t.strPtr(a.Address)
+		a.Args = t.strs(a.Args)
+		a.Value = t.strPtr(a.Value)
+		a.Transfers = t.transfers(a.Transfers)
+		a.Inputs = t.inputs(a.Inputs)
+		a.Next = t.strPtr(a.Next)
+		return a
+	case types.WarpContractAction:
+		a.Label = t.str(a.Label)
+		a.Description = t.strPtr(a.Description)
+		a.Address = t.str(a.Address)
+		a.Func = t.strPtr(a.Func)
+		a.Args = t.strs(a.Args)
+		a.Value = t.strPtr(a.Value)
+		a.Transfers = t.transfers(a.Transfers)
+		a.ABI = t.strPtr(a.ABI)
+		a.Inputs = t.inputs(a.Inputs)
+		a.Next = t.strPtr(a.Next)
+		return a
+	case types.WarpQueryAction:
+		a.Label = t.str(a.Label)
+		a.Description = t.strPtr(a.Description)
+		a.Address = t.str(a.Address)
+		a.Func = t.str(a.Func)
+		a.Args = t.strs(a.Args)
+		a.ABI = t.strPtr(a.ABI)
+		a.Inputs = t.inputs(a.Inputs)
+		a.Next = t.strPtr(a.Next)
+		return a
+	case types.WarpCollectAction:
+		a.Label = t.str(a.Label)
+		a.Description = t.strPtr(a.Description)
+		a.Destination.URL = t.str(a.Destination.URL)
+		if a.Destination.Headers != nil {
+			headers := make(map[string]string, len(a.Destination.Headers))
+			for name, value := range a.Destination.Headers {
+				headers[name] = t.str(value)
+			}
+			a.Destination.Headers = headers
+		}
+		a.Inputs = t.inputs(a.Inputs)
+		a.Next = t.strPtr(a.Next)
+		return a
+	case types.WarpLinkAction:
+		a.Label = t.str(a.Label)
+		a.Description = t.strPtr(a.Description)
+		a.URL = t.str(a.URL)
+		a.Inputs = t.inputs(a.Inputs)
+		a.Next = t.strPtr(a.Next)
+		return a
+	}
+	return action
+}
+
+// transfers copies token transfers, replacing placeholders
+func (t *templater) transfers(transfers []types.WarpContractActionTransfer) []types.WarpContractActionTransfer {
+	if transfers == nil {
+		return nil
+	}
+	result := make([]types.WarpContractActionTransfer, len(transfers))
+	for i, transfer := range transfers {
+		result[i] = transfer
+		result[i].Token = t.str(transfer.Token)
+		result[i].Amount = t.strPtr(transfer.Amount)
+		if transfer.Nonce != nil {
+			nonce := *transfer.Nonce
+			result[i].Nonce = &nonce
+		}
+	}
+	return result
+}
+
+// inputs copies action inputs, replacing placeholders
+func (t *templater) inputs(inputs []types.WarpActionInput) []types.WarpActionInput {
+	if inputs == nil {
+		return nil
+	}
+	result := make([]types.WarpActionInput, len(inputs))
+	for i, input := range inputs {
+		result[i] = input
+		result[i].Description = t.strPtr(input.Description)
+		result[i].Min = t.bound(input.Min)
+		result[i].Max = t.bound(input.Max)
+		result[i].Options = t.strs(input.Options)
+		result[i].As = copyStr(input.As)
+		result[i].Bot = copyStr(input.Bot)
+		result[i].Pattern = copyStr(input.Pattern)
+		result[i].PatternDescription = copyStr(input.PatternDescription)
+		result[i].Modifier = copyStr(input.Modifier)
+		if input.Required != nil {
+			required := *input.Required
+			result[i].Required = &required
+		}
+	}
+	return result
+}
+
+// copyStr copies a string pointer
+func copyStr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	value := *s
+	return &value
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+func TestApplyVars(t *testing.T) {
+	description := "Send {{AMOUNT}} EGLD"
+	receiver := "{{RECEIVER}}"
+	fn := "stake"
+	warp := &types.Warp{
+		Protocol:    "warp-0.0.2",
+		Title:       "Stake with {{ VALIDATOR }}",
+		Description: &description,
+		Vars: map[types.WarpVarPlaceholder]string{
+			"AMOUNT":    "1",
+			"VALIDATOR": "Default validator",
+		},
+		Actions: []types.WarpAction{
+			types.WarpContractAction{
+				Type:     types.ContractActionType,
+				Label:    "Stake",
+				Address:  "{{CONTRACT}}",
+				Func:     &fn,
+				Args:     []string{"biguint:{{AMOUNT}}"},
+				GasLimit: 5000000,
+				Inputs: []types.WarpActionInput{
+					{Name: "amount", Type: "biguint", Position: types.ValuePosition, Min: "{{AMOUNT}}", Max: float64(10)},
+				},
+			},
+			types.WarpTransferAction{Type: types.TransferActionType, Label: "Send", Address: &receiver},
+		},
+	}
+
+	config := types.WarpConfig{Vars: map[string]string{"AMOUNT": "5", "CONTRACT": "erd1qqq"}}
+	result, unresolved := ApplyVars(warp, config, nil)
+
+	if result.Title != "Stake with Default validator" || *result.Description != "Send 5 EGLD" {
+		t.Errorf("ApplyVars() title = %q, description = %q", result.Title, *result.Description)
+	}
+	contract := result.Actions[0].(types.WarpContractAction)
+	if contract.Address != "erd1qqq" || contract.Args[0] != "biguint:5" || contract.Inputs[0].Min != float64(5) {
+		t.Errorf("ApplyVars() contract action = %+v", contract)
+	}
+	if transfer := result.Actions[1].(types.WarpTransferAction); *transfer.Address != "{{RECEIVER}}" {
+		t.Errorf("ApplyVars() receiver = %s, expected the placeholder to be kept", *transfer.Address)
+	}
+	if !reflect.DeepEqual(unresolved, []string{"RECEIVER"}) {
+		t.Errorf("ApplyVars() unresolved = %v, expected [RECEIVER]", unresolved)
+	}
+
+	// The original warp is untouched
+	original := warp.Actions[0].(types.WarpContractAction)
+	if warp.Title != "Stake with {{ VALIDATOR }}" || original.Args[0] != "biguint:{{AMOUNT}}" || original.Inputs[0].Min != "{{AMOUNT}}" {
+		t.Errorf("ApplyVars() modified the original warp: %+v", warp)
+	}
+	contract.Args[0] = "changed"
+	if original.Args[0] == "changed" {
+		t.Error("ApplyVars() result shares args with the original warp")
+	}
+}
+
+func TestResolveVars(t *testing.T) {
+	t.Setenv("WARP_TEST_ALLOWED", "from-env")
+	t.Setenv("WARP_TEST_SECRET", "secret")
+
+	warp := &types.Warp{Vars: map[types.WarpVarPlaceholder]string{
+		"AMOUNT":   "query:amount",
+		"MISSING":  "query:missing",
+		"NETWORK":  "env:WARP_TEST_ALLOWED",
+		"SECRET":   "env:WARP_TEST_SECRET",
+		"WALLET":   "user:wallet",
+		"FEE":      "10",
+		"OVERRIDE": "query:amount",
+		"HOMEPAGE": "https://example.com",
+	}}
+	config := types.WarpConfig{
+		CurrentURL:     "https://usewarp.to/to?warp=stake&amount=42",
+		UserAddress:    "erd1user",
+		AllowedEnvVars: []string{"WARP_TEST_ALLOWED"},
+		Vars:           map[string]string{"FEE": "20", "OVERRIDE": "from-config"},
+	}
+
+	values := ResolveVars(warp, config, map[string]string{"OVERRIDE": "from-call"})
+	expected := map[string]string{
+		"AMOUNT":   "42",
+		"NETWORK":  "from-env",
+		"WALLET":   "erd1user",
+		"FEE":      "20",
+		"OVERRIDE": "from-call",
+		"HOMEPAGE": "https://example.com",
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("ResolveVars() = %v, expected %v", values, expected)
+	}
+
+	warp.Title = "{{SECRET}} {{MISSING}}"
+	if _, unresolved := ApplyVars(warp, config, nil); !reflect.DeepEqual(unresolved, []string{"MISSING", "SECRET"}) {
+		t.Errorf("ApplyVars() unresolved = %v, expected vars without a value to stay unresolved", unresolved)
+	}
+}
@@ -0,0 +1,241 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/abi"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/constants"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// Issue codes reported by ValidateAgainstAbis
+const (
+	CodeUnknownEndpoint = "abi.endpoint.unknown"
+	CodeArgCount        = "abi.args.count"
+	CodeArgType         = "abi.args.type"
+	CodeNotPayable      = "abi.payable"
+	CodeNotReadonly     = "abi.readonly"
+	CodeInvalidAbiType  = "abi.type.invalid"
+)
+
+// ValidateAgainstAbis checks the contract and query actions of a warp against the ABIs of their
+// contracts, keyed by action index: the function must be an endpoint of the contract, the static args
+// and arg:N inputs must match its inputs, payments must match its payable tokens, and queries must
+// target readonly endpoints. Actions without an ABI are skipped.
+//
+// The ABIs can be resolved with AbiBuilder.ResolveForAction.
+func (v *WarpValidator) ValidateAgainstAbis(warp *types.Warp, abis map[int]*types.AbiContents) *Report {
+	report := newReport()
+	if warp == nil {
+		report.addError("", CodeNil, "warp is nil")
+		return report
+	}
+
+	for i, action := range warp.Actions {
+		contents := abis[i]
+		if contents == nil {
+			continue
+		}
+		path := pointer("", "actions", i)
+
+		switch a := action.(type) {
+		case types.WarpContractAction:
+			endpoint := checkEndpoint(report, path, contents, a.Func)
+			if endpoint == nil {
+				continue
+			}
+			checkAbiArgs(report, path, contents, endpoint, a.Args, a.Inputs)
+			checkPayments(report, path, endpoint, a)
+		case types.WarpQueryAction:
+			endpoint := checkEndpoint(report, path, contents, &a.Func)
+			if endpoint == nil {
+				continue
+			}
+			checkAbiArgs(report, path, contents, endpoint, a.Args, a.Inputs)
+			if !endpoint.IsReadonly() {
+				report.addError(pointer(path, "func"), CodeNotReadonly, "queries can only target readonly endpoints, %s is %s", endpoint.Name, endpoint.Mutability)
+			}
+		}
+	}
+
+	return report
+}
+
+// checkEndpoint returns the endpoint called by an action, reporting it if the ABI does not declare it
+func checkEndpoint(report *Report, path string, contents *types.AbiContents, fn *string) *types.AbiEndpoint {
+	if fn == nil || *fn == "" {
+		return nil
+	}
+	endpoint, found := contents.Endpoint(*fn)
+	if !found {
+		report.addError(pointer(path, "func"), CodeUnknownEndpoint, "%s is not an endpoint of the contract", *fn)
+		return nil
+	}
+	return endpoint
+}
+
+// checkAbiArgs checks the number and types of the arguments given by static args and arg:N inputs
+// against the inputs of the endpoint
+func checkAbiArgs(report *Report, path string, contents *types.AbiContents, endpoint *types.AbiEndpoint, args []string, inputs []types.WarpActionInput) {
+	// Argument types and where they come from, by 1-based index
+	argTypes := make(map[int]string)
+	argPaths := make(map[int]string)
+	count := len(args)
+	for i, arg := range args {
+		argTypes[i+1] = abi.WarpArgType(arg)
+		argPaths[i+1] = pointer(path, "args", i)
+	}
+	for j, input := range inputs {
+		index := argIndex(input.Position)
+		if index == 0 {
+			continue
+		}
+		argTypes[index] = string(input.Type)
+		argPaths[index] = pointer(path, "inputs", j, "type")
+		if index > count {
+			count = index
+		}
+	}
+
+	layout, err := newArgLayout(endpoint)
+	if err != nil {
+		report.addError(path, CodeInvalidAbiType, "invalid ABI inputs of %s: %v", endpoint.Name, err)
+		return
+	}
+	variadic := layout.variadic != ""
+	if count < layout.required || (count > len(layout.fixed) && !variadic) {
+		report.addError(path, CodeArgCount, "%s expects %s arguments, got %d", endpoint.Name, expectedCount(layout.required, len(layout.fixed), variadic), count)
+	} else if extra := count - len(layout.fixed); variadic && extra > 0 && extra%len(layout.repeated) != 0 && !spreadsArgs(argTypes, len(layout.fixed), count) {
+		report.addError(path, CodeArgCount, "%s expects variadic arguments in groups of %d, got %d", endpoint.Name, len(layout.repeated), extra)
+	}
+
+	codec := abi.NewCodec(contents)
+	for index := 1; index <= count; index++ {
+		warpType := argTypes[index]
+		if warpType == "" {
+			continue
+		}
+		abiType, found := layout.argType(index, warpType)
+		if !found {
+			continue
+		}
+		ok, err := codec.AcceptsWarpType(abiType, warpType)
+		if err != nil {
+			report.addError(argPaths[index], CodeInvalidAbiType, "invalid ABI type of %s argument %d: %v", endpoint.Name, index, err)
+			continue
+		}
+		if !ok {
+			report.addError(argPaths[index], CodeArgType, "%s argument %d is %s, %s cannot be given to it", endpoint.Name, index, abiType, warpType)
+		}
+	}
+}
+
+// argLayout lists the ABI types of the top level arguments of an endpoint, with multi value
+// inputs expanded to one argument per item
+type argLayout struct {
+	fixed    []string // arguments of the inputs before a trailing variadic input
+	required int      // number of fixed arguments that are not optional
+	variadic string   // type of the trailing variadic input, if any
+	repeated []string // arguments repeated by the variadic input
+}
+
+// newArgLayout returns the argument layout of an endpoint
+func newArgLayout(endpoint *types.AbiEndpoint) (*argLayout, error) {
+	layout := &argLayout{}
+	for _, input := range endpoint.Inputs {
+		wrapper := ""
+		inner := input.Type
+		for _, name := range []string{"variadic", "optional"} {
+			if strings.HasPrefix(input.Type, name+"<") && strings.HasSuffix(input.Type, ">") {
+				wrapper, inner = name, input.Type[len(name)+1:len(input.Type)-1]
+			}
+		}
+
+		argTypes, err := abi.TopLevelTypes(inner)
+		if err != nil {
+			return nil, err
+		}
+		switch wrapper {
+		case "variadic":
+			layout.variadic, layout.repeated = input.Type, argTypes
+		case "optional":
+			layout.fixed = append(layout.fixed, argTypes...)
+		default:
+			layout.fixed = append(layout.fixed, argTypes...)
+			layout.required += len(argTypes)
+		}
+	}
+	return layout, nil
+}
+
+// argType returns the ABI type of the argument at a 1-based index. Arguments beyond the fixed
+// ones belong to the variadic input, which a list or variadic warp argument fills at once.
+func (l *argLayout) argType(index int, warpType string) (string, bool) {
+	if index <= len(l.fixed) {
+		return l.fixed[index-1], true
+	}
+	if l.variadic == "" {
+		return "", false
+	}
+	if spreadsArg(warpType) {
+		return l.variadic, true
+	}
+	return l.repeated[(index-len(l.fixed)-1)%len(l.repeated)], true
+}
+
+// spreadsArgs returns whether any of the arguments after the fixed ones fills the variadic input at once
+func spreadsArgs(argTypes map[int]string, fixed int, count int) bool {
+	for index := fixed + 1; index <= count; index++ {
+		if spreadsArg(argTypes[index]) {
+			return true
+		}
+	}
+	return false
+}
+
+// spreadsArg returns whether a warp argument type is given as any number of top level arguments
+func spreadsArg(warpType string) bool {
+	name, _, _ := strings.Cut(warpType, constants.WarpConstants.ArgParamsSeparator)
+	return name == "list" || name == "variadic"
+}
+
+// expectedCount describes the number of arguments an endpoint accepts
+func expectedCount(required int, declared int, variadic bool) string {
+	switch {
+	case variadic:
+		return fmt.Sprintf("at least %d", required)
+	case required != declared:
+		return fmt.Sprintf("%d to %d", required, declared)
+	}
+	return fmt.Sprintf("%d", required)
+}
+
+// checkPayments checks that the EGLD value and token transfers of a contract action are accepted by the endpoint
+func checkPayments(report *Report, path string, endpoint *types.AbiEndpoint, action types.WarpContractAction) {
+	egld := constants.WarpConstants.EGLD.Identifier
+
+	sendsValue := action.Value != nil && *action.Value != "" && *action.Value != "0"
+	for _, input := range action.Inputs {
+		if input.Position == types.ValuePosition {
+			sendsValue = true
+		}
+	}
+	if sendsValue && !endpoint.AcceptsToken(egld) {
+		report.addError(pointer(path, "value"), CodeNotPayable, "%s does not accept %s", endpoint.Name, egld)
+	}
+
+	for k, transfer := range action.Transfers {
+		if placeholderPattern.MatchString(transfer.Token) {
+			continue
+		}
+		if !endpoint.AcceptsToken(transfer.Token) {
+			report.addError(pointer(path, "transfers", k, "token"), CodeNotPayable, "%s does not accept %s", endpoint.Name, transfer.Token)
+		}
+	}
+	for j, input := range action.Inputs {
+		if input.Position == types.TransferPosition && !endpoint.IsPayable() {
+			report.addError(pointer(path, "inputs", j, "position"), CodeNotPayable, "%s does not accept token transfers", endpoint.Name)
+		}
+	}
+}
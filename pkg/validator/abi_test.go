@@ -0,0 +1,117 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestValidateAgainstAbis(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+	contractAbi := warptest.FixtureAbi()
+	abis := map[int]*types.AbiContents{0: &contractAbi.Content, 1: &contractAbi.Content}
+
+	value := "1000"
+	warp := warptest.FixtureWarp()
+	stake := warp.Actions[0].(types.WarpContractAction)
+	stake.Value = &value
+	warp.Actions[0] = stake
+	warp.Actions = append(warp.Actions, types.WarpQueryAction{
+		Type:    types.QueryActionType,
+		Label:   "Stake",
+		Address: warptest.RegistryContract,
+		Func:    "getStake",
+		Inputs: []types.WarpActionInput{
+			{Name: "address", Type: "address", Position: types.ArgPosition(1), Source: types.FieldSource},
+		},
+	})
+	if report := warpValidator.ValidateAgainstAbis(&warp, abis); len(report.Issues) != 0 {
+		t.Fatalf("ValidateAgainstAbis() issues = %v, expected none", report.Issues)
+	}
+
+	fn := "unstake"
+	unknown := stake
+	unknown.Func = &fn
+	stake.Args = []string{"uint64:1"}
+	stake.Transfers = []types.WarpContractActionTransfer{{Token: "USDC-c76f1f"}}
+	query := warp.Actions[1].(types.WarpQueryAction)
+	query.Func = "stake"
+	query.Inputs = nil
+	wrongType := warp.Actions[1].(types.WarpQueryAction)
+	wrongType.Inputs = []types.WarpActionInput{wrongType.Inputs[0]}
+	wrongType.Inputs[0].Type = "biguint"
+	warp.Actions = []types.WarpAction{stake, query, wrongType, unknown}
+	abis[2], abis[3] = &contractAbi.Content, &contractAbi.Content
+
+	expected := map[string]string{
+		"/actions/0":                   CodeArgCount,
+		"/actions/0/transfers/0/token": CodeNotPayable,
+		"/actions/1/func":              CodeNotReadonly,
+		"/actions/2/inputs/0/type":     CodeArgType,
+		"/actions/3/func":              CodeUnknownEndpoint,
+	}
+	report := warpValidator.ValidateAgainstAbis(&warp, abis)
+	if len(report.Issues) != len(expected) {
+		t.Errorf("ValidateAgainstAbis() issues = %v, expected %d", report.Issues, len(expected))
+	}
+	for _, issue := range report.Issues {
+		if expected[issue.Path] != issue.Code {
+			t.Errorf("unexpected issue %s (%s)", issue, issue.Code)
+		}
+	}
+}
+
+func TestValidateAgainstAbisMultiValues(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+	var contents types.AbiContents
+	err := json.Unmarshal([]byte(`{"endpoints": [
+		{"name": "pair", "mutability": "mutable", "inputs": [
+			{"name": "entry", "type": "multi<Address,BigUint>", "multi_arg": true}
+		], "outputs": []},
+		{"name": "batch", "mutability": "mutable", "inputs": [
+			{"name": "nonce", "type": "u64"},
+			{"name": "entries", "type": "variadic<multi<Address,BigUint>>", "multi_arg": true}
+		], "outputs": []},
+		{"name": "sum", "mutability": "mutable", "inputs": [
+			{"name": "values", "type": "variadic<u32>", "multi_arg": true}
+		], "outputs": []}
+	]}`), &contents)
+	if err != nil {
+		t.Fatalf("invalid test ABI: %v", err)
+	}
+
+	contract := func(fn string, args ...string) types.WarpContractAction {
+		return types.WarpContractAction{Type: types.ContractActionType, Label: fn, Func: &fn, Args: args}
+	}
+	alice := "address:" + warptest.Creator
+
+	cases := []struct {
+		action   types.WarpContractAction
+		expected map[string]string
+	}{
+		{contract("pair", alice, "biguint:5"), map[string]string{}},
+		{contract("pair", alice), map[string]string{"/actions/0": CodeArgCount}},
+		{contract("pair", "biguint:5", alice), map[string]string{"/actions/0/args/0": CodeArgType, "/actions/0/args/1": CodeArgType}},
+		{contract("batch", "uint64:1"), map[string]string{}},
+		{contract("batch", "uint64:1", alice, "biguint:5", alice, "biguint:6"), map[string]string{}},
+		{contract("batch", "uint64:1", alice, "biguint:5", alice), map[string]string{"/actions/0": CodeArgCount}},
+		{contract("batch", "uint64:1", alice, "biguint:5", "biguint:6", "biguint:7"), map[string]string{"/actions/0/args/3": CodeArgType}},
+		{contract("sum", "uint32:1", "uint8:2", "uint64:3"), map[string]string{"/actions/0/args/2": CodeArgType}},
+		{contract("sum", "variadic:uint32:1,2,3"), map[string]string{}},
+	}
+	for _, c := range cases {
+		warp := types.Warp{Actions: []types.WarpAction{c.action}}
+		report := warpValidator.ValidateAgainstAbis(&warp, map[int]*types.AbiContents{0: &contents})
+		if len(report.Issues) != len(c.expected) {
+			t.Errorf("ValidateAgainstAbis(%s %v) issues = %v, expected %v", *c.action.Func, c.action.Args, report.Issues, c.expected)
+			continue
+		}
+		for _, issue := range report.Issues {
+			if c.expected[issue.Path] != issue.Code {
+				t.Errorf("ValidateAgainstAbis(%s %v) unexpected issue %s (%s)", *c.action.Func, c.action.Args, issue, issue.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"net/url"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// Issue codes reported for brands
+const (
+	CodeInsecureURL  = "url.insecure"
+	CodeInvalidColor = "color.invalid"
+
+	// CodeMaxLength matches the code of schema length violations, so limits checked
+	// here are reported the same way as those of the schema
+	CodeMaxLength = "schema.maxLength"
+)
+
+// Length limits of a brand call to action, in characters
+const (
+	maxCTATitleLength       = 50
+	maxCTADescriptionLength = 200
+	maxCTALabelLength       = 25
+)
+
+// hexColorPattern matches CSS hex colors: #rgb, #rgba, #rrggbb and #rrggbbaa
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// checkBrand checks the rules of a brand that the schema cannot express: its URLs must be
+// absolute https URLs, its colors CSS hex colors and its call to action within its length limits
+func checkBrand(report *Report, brand *types.Brand) {
+	checkHTTPSURL(report, "/logo", brand.Logo)
+	if brand.URLs != nil && brand.URLs.Web != nil {
+		checkHTTPSURL(report, "/urls/web", *brand.URLs.Web)
+	}
+	if brand.CTA != nil {
+		checkHTTPSURL(report, "/cta/url", brand.CTA.URL)
+		checkMaxLength(report, "/cta/title", brand.CTA.Title, maxCTATitleLength)
+		checkMaxLength(report, "/cta/description", brand.CTA.Description, maxCTADescriptionLength)
+		checkMaxLength(report, "/cta/label", brand.CTA.Label, maxCTALabelLength)
+	}
+
+	if brand.Colors != nil {
+		checkColor(report, "/colors/primary", brand.Colors.Primary)
+		checkColor(report, "/colors/secondary", brand.Colors.Secondary)
+	}
+}
+
+// checkHTTPSURL checks that a non-empty value is an absolute https URL. Empty values are reported by the schema.
+func checkHTTPSURL(report *Report, path string, value string) {
+	parsed := checkAbsoluteURL(report, path, value)
+	if parsed != nil && parsed.Scheme != "https" {
+		report.addError(path, CodeInsecureURL, "%q must use https", value)
+	}
+}
+
+// checkAbsoluteURL checks that a non-empty value is an absolute URL and returns it parsed,
+// or nil if it is empty or was reported
+func checkAbsoluteURL(report *Report, path string, value string) *url.URL {
+	if value == "" {
+		return nil
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		report.addError(path, CodeInvalidURL, "invalid URL: %v", err)
+		return nil
+	}
+	if parsed.Host == "" {
+		report.addError(path, CodeInvalidURL, "%q is not an absolute URL", value)
+		return nil
+	}
+	return parsed
+}
+
+// checkMaxLength checks that a value has at most max characters
+func checkMaxLength(report *Report, path string, value string, max int) {
+	if length := utf8.RuneCountInString(value); length > max {
+		report.addError(path, CodeMaxLength, "length must be <= %d, but got %d", max, length)
+	}
+}
+
+// checkColor checks that a color is a CSS hex color, e.g. #1a2b3c
+func checkColor(report *Report, path string, color *string) {
+	if color == nil || hexColorPattern.MatchString(*color) {
+		return
+	}
+	report.addError(path, CodeInvalidColor, "%q is not a CSS hex color", *color)
+}
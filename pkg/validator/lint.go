@@ -0,0 +1,261 @@
+package validator
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
+)
+
+// Issue codes reported by Lint
+const (
+	CodeDuplicatePosition = "input.position.duplicate"
+	CodePositionGap       = "input.position.gap"
+	CodeOptionType        = "input.options.type"
+	CodeInvalidRange      = "input.range.invalid"
+	CodeInvalidNext       = "next.invalid"
+	CodeMissingGasLimit   = "action.gasLimit.missing"
+	CodeUndefinedVar      = "vars.undefined"
+)
+
+// placeholderPattern matches variable placeholders, e.g. {{AMOUNT}}
+var placeholderPattern = utils.VarPlaceholderPattern
+
+// Lint checks a warp for problems that a structurally valid warp can still have, such as inputs
+// targeting the same argument, options that don't match their input type or placeholders without
+// a matching var. It does not repeat the checks of ValidateReport.
+func (v *WarpValidator) Lint(warp *types.Warp) *Report {
+	report := newReport()
+	if warp == nil {
+		report.addError("", CodeNil, "warp is nil")
+		return report
+	}
+
+	lintNext(report, "/next", warp.Next)
+	for i, action := range warp.Actions {
+		if action == nil {
+			continue
+		}
+		path := pointer("", "actions", i)
+
+		lintNext(report, pointer(path, "next"), action.GetNext())
+		if contract, ok := action.(types.WarpContractAction); ok && contract.GasLimit == 0 {
+			report.addError(pointer(path, "gasLimit"), CodeMissingGasLimit, "contract actions need a gas limit")
+		}
+
+		inputs := actionInputs(action)
+		lintArgPositions(report, path, len(actionArgs(action)), inputs)
+		for j, input := range inputs {
+			lintInput(report, pointer(path, "inputs", j), input)
+		}
+	}
+	lintPlaceholders(report, warp)
+
+	return report
+}
+
+// actionInputs returns the inputs of an action
+func actionInputs(action types.WarpAction) []types.WarpActionInput {
+	switch a := action.(type) {
+	case types.WarpTransferAction:
+		return a.Inputs
+	case types.WarpContractAction:
+		return a.Inputs
+	case types.WarpQueryAction:
+		return a.Inputs
+	case types.WarpCollectAction:
+		return a.Inputs
+	case types.WarpLinkAction:
+		return a.Inputs
+	}
+	return nil
+}
+
+// actionArgs returns the static arguments of an action
+func actionArgs(action types.WarpAction) []string {
+	switch a := action.(type) {
+	case types.WarpTransferAction:
+		return a.Args
+	case types.WarpContractAction:
+		return a.Args
+	case types.WarpQueryAction:
+		return a.Args
+	}
+	return nil
+}
+
+// argIndex returns the 1-based argument index of an arg:N position, or 0 for other positions
+func argIndex(position types.WarpActionInputPosition) int {
+	value, found := strings.CutPrefix(string(position), "arg:")
+	if !found {
+		return 0
+	}
+	index, err := strconv.Atoi(value)
+	if err != nil || index < 1 {
+		return 0
+	}
+	return index
+}
+
+// lintArgPositions reports inputs sharing an argument, and arguments that neither a static arg nor an input provides
+func lintArgPositions(report *Report, path string, staticArgs int, inputs []types.WarpActionInput) {
+	provided := make(map[int]bool)
+	for i := 1; i <= staticArgs; i++ {
+		provided[i] = true
+	}
+
+	targeted := make(map[int]int)
+	highest := staticArgs
+	for j, input := range inputs {
+		index := argIndex(input.Position)
+		if index == 0 {
+			continue
+		}
+		if first, found := targeted[index]; found {
+			report.addError(pointer(path, "inputs", j, "position"), CodeDuplicatePosition,
+				"%s is already targeted by input %d", input.Position, first)
+			continue
+		}
+		targeted[index] = j
+		provided[index] = true
+		if index > highest {
+			highest = index
+		}
+	}
+
+	var missing []int
+	for i := 1; i <= highest; i++ {
+		if !provided[i] {
+			missing = append(missing, i)
+		}
+	}
+	for _, index := range missing {
+		report.addWarning(pointer(path, "inputs"), CodePositionGap, "no static arg or input provides %s", types.ArgPosition(index))
+	}
+}
+
+// lintInput reports options that don't match the input type, and a minimum above the maximum
+func lintInput(report *Report, path string, input types.WarpActionInput) {
+	for k, option := range input.Options {
+		if placeholderPattern.MatchString(option) {
+			continue
+		}
+		if !matchesInputType(input.Type, option) {
+			report.addError(pointer(path, "options", k), CodeOptionType, "%q is not a valid %s", option, input.Type)
+		}
+	}
+
+	min, minOk := toNumber(input.Min)
+	max, maxOk := toNumber(input.Max)
+	if minOk && maxOk && min.Cmp(max) > 0 {
+		report.addError(pointer(path, "min"), CodeInvalidRange, "min %s is greater than max %s", min.String(), max.String())
+	}
+}
+
+// matchesInputType checks if a value can be given to an input of the type. Types without
+// a fixed format, such as strings or tokens, accept any value.
+func matchesInputType(inputType types.WarpActionInputType, value string) bool {
+	switch types.BaseWarpActionInputType(inputType) {
+	case types.Uint8InputType:
+		_, err := strconv.ParseUint(value, 10, 8)
+		return err == nil
+	case types.Uint16InputType:
+		_, err := strconv.ParseUint(value, 10, 16)
+		return err == nil
+	case types.Uint32InputType:
+		_, err := strconv.ParseUint(value, 10, 32)
+		return err == nil
+	case types.Uint64InputType:
+		_, err := strconv.ParseUint(value, 10, 64)
+		return err == nil
+	case types.BigUintInputType:
+		n, ok := new(big.Int).SetString(value, 10)
+		return ok && n.Sign() >= 0
+	case types.BoolInputType:
+		_, err := strconv.ParseBool(value)
+		return err == nil
+	case types.AddressInputType:
+		return address.IsValid(value)
+	}
+	return true
+}
+
+// toNumber converts a numeric min or max to a big.Float. Placeholders are not numbers.
+func toNumber(value interface{}) (*big.Float, bool) {
+	switch n := value.(type) {
+	case float64:
+		return big.NewFloat(n), true
+	case int:
+		return new(big.Float).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Float).SetInt64(n), true
+	case json.Number:
+		f, _, err := big.ParseFloat(string(n), 10, 256, big.ToNearestEven)
+		return f, err == nil
+	}
+	return nil, false
+}
+
+// lintNext reports a next reference that is neither a warp identifier nor a URL
+func lintNext(report *Report, path string, next *string) {
+	if next == nil || placeholderPattern.MatchString(*next) {
+		return
+	}
+	if parsed, err := url.Parse(*next); err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" {
+		return
+	}
+	if info := utils.GetInfoFromPrefixedIdentifier(*next); info != nil && info.ID != "" {
+		return
+	}
+	report.addError(path, CodeInvalidNext, "%q is neither a warp identifier nor a URL", *next)
+}
+
+// lintPlaceholders reports placeholders in any string of the warp that have no matching var
+func lintPlaceholders(report *Report, warp *types.Warp) {
+	data, err := json.Marshal(warp)
+	if err != nil {
+		return
+	}
+	var document map[string]interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return
+	}
+	// Vars and meta are not templated
+	delete(document, "vars")
+	delete(document, "meta")
+
+	walkStrings("", document, func(path string, value string) {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(value, -1) {
+			if _, found := warp.Vars[types.WarpVarPlaceholder(match[1])]; !found {
+				report.addError(path, CodeUndefinedVar, "placeholder %s has no matching var", match[0])
+			}
+		}
+	})
+}
+
+// walkStrings calls fn with the JSON pointer and value of every string in a decoded JSON document, in a stable order
+func walkStrings(path string, value interface{}, fn func(path string, value string)) {
+	switch v := value.(type) {
+	case string:
+		fn(path, v)
+	case []interface{}:
+		for i, item := range v {
+			walkStrings(pointer(path, i), item, fn)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			walkStrings(pointer(path, key), v[key], fn)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestLint(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+
+	warp := warptest.FixtureWarp()
+	if report := warpValidator.Lint(&warp); len(report.Issues) != 0 {
+		t.Fatalf("Lint() issues = %v, expected none", report.Issues)
+	}
+
+	next := "nowhere at all"
+	warp.Next = &next
+	warp.Title = "Stake {{AMOUNT}} with {{VALIDATOR}}"
+	warp.Vars = map[types.WarpVarPlaceholder]string{"AMOUNT": "query:amount"}
+
+	action := warp.Actions[0].(types.WarpContractAction)
+	action.GasLimit = 0
+	action.Args = []string{"uint64:1"}
+	action.Inputs = []types.WarpActionInput{
+		{Name: "first", Type: "uint8", Position: types.ArgPosition(2), Source: types.FieldSource, Options: []string{"1", "256"}},
+		{Name: "second", Type: "biguint", Position: types.ArgPosition(2), Source: types.FieldSource, Min: float64(10), Max: float64(5)},
+		{Name: "fourth", Type: "address", Position: types.ArgPosition(4), Source: types.FieldSource, Options: []string{warptest.Creator}},
+	}
+	warp.Actions[0] = action
+
+	expected := map[string]string{
+		"/next":                         CodeInvalidNext,
+		"/title":                        CodeUndefinedVar,
+		"/actions/0/gasLimit":           CodeMissingGasLimit,
+		"/actions/0/inputs/0/options/1": CodeOptionType,
+		"/actions/0/inputs/1/position":  CodeDuplicatePosition,
+		"/actions/0/inputs/1/min":       CodeInvalidRange,
+		"/actions/0/inputs":             CodePositionGap,
+	}
+
+	report := warpValidator.Lint(&warp)
+	if len(report.Issues) != len(expected) {
+		t.Errorf("Lint() issues = %v, expected %d", report.Issues, len(expected))
+	}
+	for _, issue := range report.Issues {
+		if expected[issue.Path] != issue.Code {
+			t.Errorf("unexpected issue %s (%s)", issue, issue.Code)
+		}
+		if issue.Code == CodePositionGap && issue.Severity != SeverityWarning {
+			t.Errorf("issue %s severity = %s, expected a warning", issue, issue.Severity)
+		}
+	}
+	if issue := report.Errors()[0]; issue.Category() != "next" {
+		t.Errorf("Category() = %s, expected next", issue.Category())
+	}
+}
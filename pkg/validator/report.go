@@ -0,0 +1,132 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity represents how serious a validation issue is
+type Severity string
+
+const (
+	// SeverityError marks issues that make a warp or brand invalid
+	SeverityError Severity = "error"
+
+	// SeverityWarning marks issues that are allowed but likely mistakes
+	SeverityWarning Severity = "warning"
+)
+
+// Issue codes reported by the validator. Schema violations are reported as
+// "schema.{keyword}", e.g. "schema.required" or "schema.maxLength".
+const (
+	CodeNil               = "nil"
+	CodeInvalidProtocol   = "protocol.invalid"
+	CodeNewerProtocol     = "protocol.newer"
+	CodeUnsupportedAction = "action.unsupported"
+	CodeInvalidURL        = "url.invalid"
+	CodeEncoding          = "encoding"
+)
+
+// Issue represents a single validation problem
+type Issue struct {
+	Path     string   `json:"path"` // JSON pointer to the offending value, "" for the document itself
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+}
+
+// String returns the issue as "path: message"
+func (i Issue) String() string {
+	if i.Path == "" {
+		return i.Message
+	}
+	return i.Path + ": " + i.Message
+}
+
+// Category returns the category of the issue, the first segment of its code, e.g. "input" for "input.range.invalid"
+func (i Issue) Category() string {
+	if index := strings.Index(i.Code, "."); index >= 0 {
+		return i.Code[:index]
+	}
+	return i.Code
+}
+
+// Report represents the result of a validation, listing every issue found
+type Report struct {
+	Issues []Issue `json:"issues"`
+}
+
+// newReport creates an empty report
+func newReport() *Report {
+	return &Report{Issues: []Issue{}}
+}
+
+// Valid returns whether the report has no errors. Warnings do not make it invalid.
+func (r *Report) Valid() bool {
+	return len(r.Errors()) == 0
+}
+
+// Errors returns the issues with error severity
+func (r *Report) Errors() []Issue {
+	return r.filter(SeverityError)
+}
+
+// Warnings returns the issues with warning severity
+func (r *Report) Warnings() []Issue {
+	return r.filter(SeverityWarning)
+}
+
+// Err returns a *ReportError if the report has errors, or nil
+func (r *Report) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return &ReportError{Report: r}
+}
+
+// filter returns the issues with the severity
+func (r *Report) filter(severity Severity) []Issue {
+	issues := []Issue{}
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// addError adds an error to the report
+func (r *Report) addError(path string, code string, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{Path: path, Severity: SeverityError, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// addWarning adds a warning to the report
+func (r *Report) addWarning(path string, code string, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{Path: path, Severity: SeverityWarning, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// ReportError is returned by Validate and ValidateBrand when the report has errors
+type ReportError struct {
+	Report *Report
+}
+
+// Error returns the first error of the report, and how many more were found
+func (e *ReportError) Error() string {
+	errs := e.Report.Errors()
+	message := "WarpValidator: " + errs[0].String()
+	if len(errs) > 1 {
+		message += fmt.Sprintf(" (and %d more errors)", len(errs)-1)
+	}
+	return message
+}
+
+// pointer appends reference tokens to a JSON pointer, escaping them as per RFC 6901
+func pointer(base string, tokens ...interface{}) string {
+	var b strings.Builder
+	b.WriteString(base)
+	for _, token := range tokens {
+		escaped := strings.NewReplacer("~", "~0", "/", "~1").Replace(fmt.Sprint(token))
+		b.WriteString("/" + escaped)
+	}
+	return b.String()
+}
@@ -0,0 +1,180 @@
+package validator
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/protocol"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// schemaFiles holds the bundled schemas, named {protocol identifier}.schema.json.
+// They are maintained by hand after the published schemas and are not copies of them.
+//
+//go:embed schemas/*.schema.json
+var schemaFiles embed.FS
+
+var (
+	bundledSchemasOnce sync.Once
+	bundledSchemas     map[string]*jsonschema.Schema
+	bundledSchemasErr  error
+)
+
+// loadBundledSchemas compiles the bundled schemas, keyed by protocol identifier
+func loadBundledSchemas() (map[string]*jsonschema.Schema, error) {
+	bundledSchemasOnce.Do(func() {
+		bundledSchemas = make(map[string]*jsonschema.Schema)
+
+		paths, err := fs.Glob(schemaFiles, "schemas/*.schema.json")
+		if err != nil {
+			bundledSchemasErr = err
+			return
+		}
+		for _, path := range paths {
+			data, err := schemaFiles.ReadFile(path)
+			if err != nil {
+				bundledSchemasErr = err
+				return
+			}
+			schema, err := compileSchema(path, data)
+			if err != nil {
+				bundledSchemasErr = err
+				return
+			}
+			protocol := strings.TrimSuffix(strings.TrimPrefix(path, "schemas/"), ".schema.json")
+			bundledSchemas[protocol] = schema
+		}
+	})
+
+	return bundledSchemas, bundledSchemasErr
+}
+
+// compileSchema compiles a JSON schema. Schemas without $schema are read as draft 2020-12,
+// other drafts such as draft-07 are selected by their $schema.
+func compileSchema(name string, data []byte) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if err := compiler.AddResource(name, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", name, err)
+	}
+	schema, err := compiler.Compile(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", name, err)
+	}
+	return schema, nil
+}
+
+// schemaFor selects the schema for a protocol identifier, e.g. "warp-0.0.2", and returns the
+// identifier of the selected schema. Refreshed schemas take precedence over the bundled ones.
+// Versions without a schema of their own are validated with the latest schema of the protocol,
+// and versions of a newer major are rejected.
+func (v *WarpValidator) schemaFor(protocolName types.ProtocolName, identifier string) (*jsonschema.Schema, string, error) {
+	if !strings.HasPrefix(identifier, string(protocolName)+"-") {
+		return nil, "", fmt.Errorf("invalid %s protocol: %s", protocolName, identifier)
+	}
+	// Malformed versions are reported by the schema pattern
+	var unsupported *protocol.UnsupportedVersionError
+	if _, err := protocol.CheckCompatibility(protocolName, identifier); errors.As(err, &unsupported) {
+		return nil, "", err
+	}
+
+	bundled, err := loadBundledSchemas()
+	if err != nil {
+		return nil, "", err
+	}
+
+	candidates := []string{identifier, latestSchemaProtocol(bundled, protocolName)}
+	for _, candidate := range candidates {
+		v.mutex.RLock()
+		schema, found := v.schemas[candidate]
+		v.mutex.RUnlock()
+		if found {
+			return schema, candidate, nil
+		}
+		if schema, found := bundled[candidate]; found {
+			return schema, candidate, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("no schema for protocol %s", identifier)
+}
+
+// latestSchemaProtocol returns the newest protocol identifier with a bundled schema
+func latestSchemaProtocol(schemas map[string]*jsonschema.Schema, protocolName types.ProtocolName) string {
+	var protocols []string
+	for protocol := range schemas {
+		if strings.HasPrefix(protocol, string(protocolName)+"-") {
+			protocols = append(protocols, protocol)
+		}
+	}
+	if len(protocols) == 0 {
+		return ""
+	}
+
+	sort.Slice(protocols, func(i, j int) bool {
+		return compareVersions(protocols[i], protocols[j]) < 0
+	})
+	return protocols[len(protocols)-1]
+}
+
+// compareVersions compares the versions of two protocol identifiers
+func compareVersions(a string, b string) int {
+	idA, _ := protocol.Parse(a)
+	idB, _ := protocol.Parse(b)
+	return idA.Version.Compare(idB.Version)
+}
+
+// checkSchema validates a value against the schema of its protocol, and reports each violation
+func (v *WarpValidator) checkSchema(report *Report, protocolName types.ProtocolName, protocol string, value interface{}) {
+	schema, schemaProtocol, err := v.schemaFor(protocolName, protocol)
+	if err != nil {
+		report.addError("/protocol", CodeInvalidProtocol, "%v", err)
+		return
+	}
+	if compareVersions(protocol, schemaProtocol) > 0 {
+		report.addWarning("/protocol", CodeNewerProtocol, "%s is newer than the latest known schema, it was validated against %s", protocol, schemaProtocol)
+	}
+
+	// The schema validates the JSON form of the value
+	data, err := json.Marshal(value)
+	if err != nil {
+		report.addError("", CodeEncoding, "cannot encode %s: %v", protocolName, err)
+		return
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		report.addError("", CodeEncoding, "cannot encode %s: %v", protocolName, err)
+		return
+	}
+
+	err = schema.Validate(document)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return
+	}
+	for _, leaf := range leaves(validationErr) {
+		keyword := leaf.KeywordLocation[strings.LastIndex(leaf.KeywordLocation, "/")+1:]
+		report.addError(leaf.InstanceLocation, "schema."+keyword, "%s", leaf.Message)
+	}
+}
+
+// leaves returns the most specific causes of a validation error
+func leaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var result []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		result = append(result, leaves(cause)...)
+	}
+	return result
+}
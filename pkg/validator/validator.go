@@ -1,309 +1,168 @@
 package validator
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/url"
-	"regexp"
-	
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/utils"
 )
 
 // WarpValidator provides functionality for validating warps
 type WarpValidator struct {
-	config types.WarpConfig
-	schema map[string]interface{}
+	config  types.WarpConfig
+	mutex   sync.RWMutex
+	schemas map[string]*jsonschema.Schema // refreshed schemas, keyed by protocol identifier
 }
 
 // NewWarpValidator creates a new WarpValidator instance
 func NewWarpValidator(config types.WarpConfig) *WarpValidator {
 	return &WarpValidator{
-		config: config,
-		schema: nil,
+		config:  config,
+		schemas: make(map[string]*jsonschema.Schema),
 	}
 }
 
-// Validate validates a warp against the schema
+// Validate validates a warp, returning a *ReportError listing its errors if it is invalid
 func (v *WarpValidator) Validate(warp *types.Warp) error {
+	return v.ValidateReport(warp).Err()
+}
+
+// ValidateReport validates a warp against the schema of its protocol version and the rules
+// the schema cannot express, and reports every issue found
+func (v *WarpValidator) ValidateReport(warp *types.Warp) *Report {
+	report := newReport()
 	if warp == nil {
-		return errors.New("WarpValidator: warp is nil")
+		report.addError("", CodeNil, "warp is nil")
+		return report
 	}
 
-	// Basic validation - ensure required fields are present
-	if warp.Protocol == "" {
-		return errors.New("WarpValidator: protocol is required")
-	}
-	if warp.Name == "" {
-		return errors.New("WarpValidator: name is required")
-	}
-	if warp.Title == "" {
-		return errors.New("WarpValidator: title is required")
-	}
-	if len(warp.Actions) == 0 {
-		return errors.New("WarpValidator: at least one action is required")
-	}
+	v.checkSchema(report, types.WarpProtocol, warp.Protocol, warp)
 
-	// Validate each action
 	for i, action := range warp.Actions {
-		if err := v.validateAction(action); err != nil {
-			return fmt.Errorf("WarpValidator: invalid action at index %d: %w", i, err)
-		}
+		v.checkAction(report, pointer("", "actions", i), action)
 	}
 
-	// In a real implementation, you would validate against a JSON schema
-	// For this example, we'll just do some basic validation
-	
-	return nil
+	return report
 }
 
-// validateAction validates a warp action
-func (v *WarpValidator) validateAction(action types.WarpAction) error {
-	if action == nil {
-		return errors.New("action is nil")
-	}
-
-	actionType := action.GetType()
-	label := action.GetLabel()
-
-	if actionType == "" {
-		return errors.New("action type is required")
-	}
-	if label == "" {
-		return errors.New("action label is required")
-	}
-
-	// Validate based on action type
-	switch actionType {
-	case types.TransferActionType:
-		if a, ok := action.(types.WarpTransferAction); ok {
-			return v.validateTransferAction(a)
-		}
-	case types.ContractActionType:
-		if a, ok := action.(types.WarpContractAction); ok {
-			return v.validateContractAction(a)
-		}
-	case types.QueryActionType:
-		if a, ok := action.(types.WarpQueryAction); ok {
-			return v.validateQueryAction(a)
-		}
-	case types.CollectActionType:
-		if a, ok := action.(types.WarpCollectAction); ok {
-			return v.validateCollectAction(a)
-		}
-	case types.LinkActionType:
-		if a, ok := action.(types.WarpLinkAction); ok {
-			return v.validateLinkAction(a)
-		}
-	default:
-		return fmt.Errorf("unsupported action type: %s", actionType)
-	}
-
-	return nil
+// ValidateBrand validates a brand, returning a *ReportError listing its errors if it is invalid
+func (v *WarpValidator) ValidateBrand(brand *types.Brand) error {
+	return v.ValidateBrandReport(brand).Err()
 }
 
-// validateTransferAction validates a transfer action
-func (v *WarpValidator) validateTransferAction(action types.WarpTransferAction) error {
-	// Validate required fields
-	if action.Type != types.TransferActionType {
-		return fmt.Errorf("expected action type %s, got %s", types.TransferActionType, action.Type)
+// ValidateBrandReport validates a brand against the schema of its protocol version and the rules
+// the schema cannot express, such as https URLs and hex colors, and reports every issue found
+func (v *WarpValidator) ValidateBrandReport(brand *types.Brand) *Report {
+	report := newReport()
+	if brand == nil {
+		report.addError("", CodeNil, "brand is nil")
+		return report
 	}
 
-	// Validate inputs
-	if action.Inputs != nil {
-		for i, input := range action.Inputs {
-			if err := v.validateInput(input); err != nil {
-				return fmt.Errorf("invalid input at index %d: %w", i, err)
-			}
-		}
-	}
+	v.checkSchema(report, types.BrandProtocol, brand.Protocol, brand)
+	checkBrand(report, brand)
 
-	return nil
+	return report
 }
 
-// validateContractAction validates a contract action
-func (v *WarpValidator) validateContractAction(action types.WarpContractAction) error {
-	// Validate required fields
-	if action.Type != types.ContractActionType {
-		return fmt.Errorf("expected action type %s, got %s", types.ContractActionType, action.Type)
-	}
-	if action.Address == "" {
-		return errors.New("contract address is required")
+// ValidateAbi validates an ABI inscription
+func (v *WarpValidator) ValidateAbi(abi *types.WarpAbi) error {
+	if abi == nil {
+		return errors.New("WarpValidator: ABI is nil")
 	}
 
-	// Validate inputs
-	if action.Inputs != nil {
-		for i, input := range action.Inputs {
-			if err := v.validateInput(input); err != nil {
-				return fmt.Errorf("invalid input at index %d: %w", i, err)
-			}
-		}
+	if !strings.HasPrefix(abi.Protocol, string(types.AbiProtocol)+"-") {
+		return fmt.Errorf("WarpValidator: invalid ABI protocol: %s", abi.Protocol)
 	}
-
-	return nil
-}
-
-// validateQueryAction validates a query action
-func (v *WarpValidator) validateQueryAction(action types.WarpQueryAction) error {
-	// Validate required fields
-	if action.Type != types.QueryActionType {
-		return fmt.Errorf("expected action type %s, got %s", types.QueryActionType, action.Type)
-	}
-	if action.Address == "" {
-		return errors.New("query address is required")
-	}
-	if action.Func == "" {
-		return errors.New("query function is required")
-	}
-
-	// Validate inputs
-	if action.Inputs != nil {
-		for i, input := range action.Inputs {
-			if err := v.validateInput(input); err != nil {
-				return fmt.Errorf("invalid input at index %d: %w", i, err)
-			}
-		}
+	if len(abi.Content.Endpoints) == 0 {
+		return errors.New("WarpValidator: ABI must define at least one endpoint")
 	}
 
 	return nil
 }
 
-// validateCollectAction validates a collect action
-func (v *WarpValidator) validateCollectAction(action types.WarpCollectAction) error {
-	// Validate required fields
-	if action.Type != types.CollectActionType {
-		return fmt.Errorf("expected action type %s, got %s", types.CollectActionType, action.Type)
-	}
-	if action.Destination.URL == "" {
-		return errors.New("destination URL is required")
-	}
-
-	// Validate URL
-	_, err := url.Parse(action.Destination.URL)
-	if err != nil {
-		return fmt.Errorf("invalid destination URL: %w", err)
-	}
-
-	// Validate method
-	if action.Destination.Method != types.GET && action.Destination.Method != types.POST {
-		return fmt.Errorf("unsupported HTTP method: %s", action.Destination.Method)
-	}
-
-	// Validate inputs
-	if action.Inputs != nil {
-		for i, input := range action.Inputs {
-			if err := v.validateInput(input); err != nil {
-				return fmt.Errorf("invalid input at index %d: %w", i, err)
-			}
-		}
+// checkAction checks the rules of an action that the schema cannot express
+func (v *WarpValidator) checkAction(report *Report, path string, action types.WarpAction) {
+	switch a := action.(type) {
+	case types.WarpTransferAction, types.WarpContractAction, types.WarpQueryAction:
+		// fully covered by the schema
+	case types.WarpCollectAction:
+		checkURL(report, pointer(path, "destination", "url"), a.Destination.URL)
+	case types.WarpLinkAction:
+		checkURL(report, pointer(path, "url"), a.URL)
+	case nil:
+		report.addError(path, CodeNil, "action is nil")
+	default:
+		report.addError(path, CodeUnsupportedAction, "unsupported action type: %s", action.GetType())
 	}
-
-	return nil
 }
 
-// validateLinkAction validates a link action
-func (v *WarpValidator) validateLinkAction(action types.WarpLinkAction) error {
-	// Validate required fields
-	if action.Type != types.LinkActionType {
-		return fmt.Errorf("expected action type %s, got %s", types.LinkActionType, action.Type)
-	}
-	if action.URL == "" {
-		return errors.New("URL is required")
-	}
-
-	// Validate URL
-	_, err := url.Parse(action.URL)
-	if err != nil {
-		return fmt.Errorf("invalid URL: %w", err)
-	}
-
-	// Validate inputs
-	if action.Inputs != nil {
-		for i, input := range action.Inputs {
-			if err := v.validateInput(input); err != nil {
-				return fmt.Errorf("invalid input at index %d: %w", i, err)
-			}
-		}
+// checkURL checks that a non-empty value is an absolute http or https URL. Empty values are reported by the schema.
+func checkURL(report *Report, path string, value string) {
+	parsed := checkAbsoluteURL(report, path, value)
+	if parsed != nil && parsed.Scheme != "http" && parsed.Scheme != "https" {
+		report.addError(path, CodeInvalidURL, "%q must use http or https", value)
 	}
-
-	return nil
 }
 
-// validateInput validates an input
-func (v *WarpValidator) validateInput(input types.WarpActionInput) error {
-	// Validate required fields
-	if input.Name == "" {
-		return errors.New("input name is required")
+// RefreshSchemas downloads the warp and brand schemas from the configured URLs.
+// They replace the bundled schemas of the latest protocol versions for this validator.
+func (v *WarpValidator) RefreshSchemas() error {
+	warpSchemaURL := v.config.WarpSchemaURL
+	if warpSchemaURL == "" {
+		warpSchemaURL = core.Config.DefaultWarpSchemaURL(v.config.Env)
 	}
-	if input.Type == "" {
-		return errors.New("input type is required")
-	}
-	if input.Position == "" {
-		return errors.New("input position is required")
-	}
-	if input.Source == "" {
-		return errors.New("input source is required")
+	brandSchemaURL := v.config.BrandSchemaURL
+	if brandSchemaURL == "" {
+		brandSchemaURL = core.Config.DefaultBrandSchemaURL(v.config.Env)
 	}
 
-	// Validate source
-	validSources := map[types.WarpActionInputSource]bool{
-		types.FieldSource: true,
-		types.QuerySource: true,
+	schemaURLs := map[types.ProtocolName]string{
+		types.WarpProtocol:  warpSchemaURL,
+		types.BrandProtocol: brandSchemaURL,
 	}
-	if !validSources[input.Source] {
-		return fmt.Errorf("invalid input source: %s", input.Source)
-	}
-
-	// Validate position based on format
-	if input.Position == types.ReceiverPosition || input.Position == types.ValuePosition || input.Position == types.TransferPosition {
-		// These are valid static positions
-	} else {
-		// Check if it's an arg position (arg:1, arg:2, etc.)
-		argPositionPattern := regexp.MustCompile(`^arg:[1-9][0-9]*$`)
-		if !argPositionPattern.MatchString(string(input.Position)) {
-			return fmt.Errorf("invalid input position: %s", input.Position)
+	for protocolName, schemaURL := range schemaURLs {
+		schema, err := v.loadSchema(schemaURL)
+		if err != nil {
+			return fmt.Errorf("WarpValidator: failed to refresh %s schema: %w", protocolName, err)
 		}
-	}
 
-	// Additional validation based on input type could be added here
+		v.mutex.Lock()
+		v.schemas[utils.GetLatestProtocolIdentifier(protocolName)] = schema
+		v.mutex.Unlock()
+	}
 
 	return nil
 }
 
-// loadSchema loads the schema from the specified URL
-func (v *WarpValidator) loadSchema() error {
-	if v.schema != nil {
-		return nil
-	}
-
-	schemaURL := v.config.WarpSchemaURL
-	if schemaURL == "" {
-		schemaURL = core.Config.DefaultWarpSchemaURL(v.config.Env)
-	}
+// loadSchema loads and compiles the schema from the specified URL
+func (v *WarpValidator) loadSchema(schemaURL string) (schema *jsonschema.Schema, err error) {
+	done := observability.Track(v.config.Observer, observability.OpSchemaLoad)
+	defer func() { done(err) }()
 
-	resp, err := http.Get(schemaURL)
+	resp, err := utils.NewHTTPClient(v.config).Get(schemaURL)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to load schema: %s", resp.Status)
+		return nil, fmt.Errorf("failed to load schema: %s", resp.Status)
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	var schema map[string]interface{}
-	if err := json.Unmarshal(body, &schema); err != nil {
-		return err
-	}
-
-	v.schema = schema
-	return nil
-} 
\ No newline at end of file
+	return compileSchema(schemaURL, body)
+}
@@ -0,0 +1,259 @@
+package validator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestValidateSchema(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+
+	warp := warptest.FixtureWarp()
+	if err := warpValidator.Validate(&warp); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// Versions without a bundled schema use the latest one
+	warp.Protocol = "warp-0.0.1"
+	if err := warpValidator.Validate(&warp); err != nil {
+		t.Errorf("Validate() error = %v, expected the latest schema to apply", err)
+	}
+
+	warp.Protocol = "brand-0.0.2"
+	if err := warpValidator.Validate(&warp); err == nil {
+		t.Error("Validate() error = nil, expected a brand protocol to be rejected")
+	}
+
+	// Newer versions are validated against the latest schema with a warning, newer majors are rejected
+	warp.Protocol = "warp-0.5.0"
+	report := warpValidator.ValidateReport(&warp)
+	if warnings := report.Warnings(); !report.Valid() || len(warnings) != 1 || warnings[0].Code != CodeNewerProtocol {
+		t.Errorf("ValidateReport() issues = %v, expected a newer protocol warning", report.Issues)
+	}
+	warp.Protocol = "warp-1.0.0"
+	report = warpValidator.ValidateReport(&warp)
+	if errs := report.Errors(); len(errs) != 1 || errs[0].Code != CodeInvalidProtocol {
+		t.Errorf("ValidateReport() issues = %v, expected an unsupported protocol error", report.Issues)
+	}
+
+	warp = warptest.FixtureWarp()
+	warp.Title = strings.Repeat("t", 81)
+	err := warpValidator.Validate(&warp)
+	if err == nil || !strings.Contains(err.Error(), "/title") {
+		t.Errorf("Validate() error = %v, expected a /title error", err)
+	}
+
+	warp = warptest.FixtureWarp()
+	action := warp.Actions[0].(types.WarpContractAction)
+	action.Label = "A label longer than the schema allows"
+	warp.Actions[0] = action
+	err = warpValidator.Validate(&warp)
+	if err == nil || !strings.Contains(err.Error(), "/actions/0/label") {
+		t.Errorf("Validate() error = %v, expected an /actions/0/label error", err)
+	}
+}
+
+func TestValidateBrandSchema(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+
+	brand := warptest.FixtureBrand()
+	if err := warpValidator.ValidateBrand(&brand); err != nil {
+		t.Fatalf("ValidateBrand() error = %v", err)
+	}
+
+	brand.CTA = &types.BrandCTA{Title: "Get started", URL: "https://example.com"}
+	err := warpValidator.ValidateBrand(&brand)
+	if err == nil || !strings.Contains(err.Error(), "/cta/label") {
+		t.Errorf("ValidateBrand() error = %v, expected a /cta/label error", err)
+	}
+}
+
+func TestRefreshSchemas(t *testing.T) {
+	// A draft-07 schema that only allows short titles
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {"title": {"type": "string", "maxLength": 5}}
+		}`))
+	}))
+	defer server.Close()
+
+	warpValidator := NewWarpValidator(types.WarpConfig{
+		WarpSchemaURL:  server.URL + "/warp.schema.json",
+		BrandSchemaURL: server.URL + "/brand.schema.json",
+	})
+	if err := warpValidator.RefreshSchemas(); err != nil {
+		t.Fatalf("RefreshSchemas() error = %v", err)
+	}
+
+	warp := warptest.FixtureWarp()
+	if err := warpValidator.Validate(&warp); err == nil {
+		t.Error("Validate() error = nil, expected the refreshed schema to apply")
+	}
+
+	// Other validators keep the bundled schemas
+	if err := NewWarpValidator(types.WarpConfig{}).Validate(&warp); err != nil {
+		t.Errorf("Validate() error = %v, expected the bundled schema to apply", err)
+	}
+}
+
+func TestValidateReport(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+
+	warp := warptest.FixtureWarp()
+	report := warpValidator.ValidateReport(&warp)
+	if !report.Valid() || len(report.Issues) != 0 {
+		t.Fatalf("ValidateReport() issues = %v, expected none", report.Issues)
+	}
+
+	warp.Title = strings.Repeat("t", 81)
+	action := warp.Actions[0].(types.WarpContractAction)
+	action.Label = "A label longer than the schema allows"
+	warp.Actions[0] = action
+	warp.Actions = append(warp.Actions, types.WarpLinkAction{Type: types.LinkActionType, Label: "Open", URL: "https://exa mple.com/%zz"})
+
+	report = warpValidator.ValidateReport(&warp)
+	paths := make(map[string]string)
+	for _, issue := range report.Errors() {
+		paths[issue.Path] = issue.Code
+	}
+	expected := map[string]string{
+		"/title":           "schema.maxLength",
+		"/actions/0/label": "schema.maxLength",
+		"/actions/1/url":   CodeInvalidURL,
+	}
+	for path, code := range expected {
+		if paths[path] != code {
+			t.Errorf("issue at %s = %q, expected %q (issues: %v)", path, paths[path], code, report.Issues)
+		}
+	}
+
+	var reportErr *ReportError
+	err := warpValidator.Validate(&warp)
+	if !errors.As(err, &reportErr) || len(reportErr.Report.Errors()) != len(report.Errors()) {
+		t.Fatalf("Validate() error = %v, expected a *ReportError with every error", err)
+	}
+	if !strings.Contains(err.Error(), "more errors") {
+		t.Errorf("Validate() error = %v, expected the count of further errors", err)
+	}
+
+	if report := warpValidator.ValidateReport(nil); report.Valid() || report.Issues[0].Code != CodeNil {
+		t.Errorf("ValidateReport(nil) issues = %v, expected a nil error", report.Issues)
+	}
+}
+
+func TestValidateActionURLs(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+
+	urls := map[string]string{
+		"https://example.com/page": "",
+		"http://example.com/page":  "",
+		"/relative/page":           CodeInvalidURL,
+		"example.com/page":         CodeInvalidURL,
+		"ftp://example.com/file":   CodeInvalidURL,
+	}
+	for value, code := range urls {
+		warp := warptest.FixtureWarp()
+		warp.Actions = append(warp.Actions, types.WarpLinkAction{Type: types.LinkActionType, Label: "Open", URL: value})
+
+		report := warpValidator.ValidateReport(&warp)
+		issue := ""
+		for _, i := range report.Errors() {
+			if i.Path == "/actions/1/url" {
+				issue = i.Code
+			}
+		}
+		if issue != code {
+			t.Errorf("ValidateReport() issue for %q = %q, expected %q (issues: %v)", value, issue, code, report.Issues)
+		}
+	}
+}
+
+// unencodableAction is an action that cannot be encoded to JSON
+type unencodableAction struct {
+	types.WarpLinkAction
+	Done chan struct{} `json:"done"`
+}
+
+func TestValidateReportEncoding(t *testing.T) {
+	warp := warptest.FixtureWarp()
+	warp.Actions = append(warp.Actions, unencodableAction{})
+
+	report := NewWarpValidator(types.WarpConfig{}).ValidateReport(&warp)
+	found := false
+	for _, issue := range report.Errors() {
+		found = found || (issue.Path == "" && issue.Code == CodeEncoding)
+	}
+	if !found {
+		t.Errorf("ValidateReport() issues = %v, expected an encoding error", report.Issues)
+	}
+}
+
+func TestReportWarnings(t *testing.T) {
+	report := newReport()
+	report.addWarning(pointer("", "actions", 0, "a/b"), "test", "suspicious")
+	if !report.Valid() || report.Err() != nil {
+		t.Error("Valid() = false, expected warnings not to invalidate a report")
+	}
+	if warnings := report.Warnings(); len(warnings) != 1 || warnings[0].Path != "/actions/0/a~1b" {
+		t.Errorf("Warnings() = %v, expected an escaped JSON pointer", warnings)
+	}
+}
+
+func TestValidateBrandRules(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+
+	brand := warptest.FixtureBrand()
+	web := "http://example.com"
+	primary, secondary := "#1a2B3c", "blue"
+	brand.Protocol = "brand-2"
+	brand.Logo = "/logo.png"
+	brand.URLs = &types.BrandURLs{Web: &web}
+	brand.Colors = &types.BrandColors{Primary: &primary, Secondary: &secondary}
+	brand.CTA = &types.BrandCTA{Title: "Get started", Label: "A label longer than allowed", URL: "https://example.com"}
+
+	expected := map[string]string{
+		"/protocol":         "schema.pattern",
+		"/logo":             CodeInvalidURL,
+		"/urls/web":         CodeInsecureURL,
+		"/colors/secondary": CodeInvalidColor,
+		"/cta/label":        CodeMaxLength,
+	}
+	report := warpValidator.ValidateBrandReport(&brand)
+	if len(report.Issues) != len(expected) {
+		t.Errorf("ValidateBrandReport() issues = %v, expected %d", report.Issues, len(expected))
+	}
+	for _, issue := range report.Issues {
+		if expected[issue.Path] != issue.Code {
+			t.Errorf("unexpected issue %s (%s)", issue, issue.Code)
+		}
+	}
+}
+
+func TestValidateBrandCTALimits(t *testing.T) {
+	warpValidator := NewWarpValidator(types.WarpConfig{})
+
+	brand := warptest.FixtureBrand()
+	brand.CTA = &types.BrandCTA{Title: strings.Repeat("é", 50), Label: "Start", URL: "https://example.com"}
+	if report := warpValidator.ValidateBrandReport(&brand); !report.Valid() {
+		t.Errorf("ValidateBrandReport() issues = %v, expected limits in characters", report.Issues)
+	}
+
+	brand.CTA.Title = strings.Repeat("é", 51)
+	brand.CTA.Description = strings.Repeat("d", 201)
+	report := warpValidator.ValidateBrandReport(&brand)
+	paths := make(map[string]string)
+	for _, issue := range report.Errors() {
+		paths[issue.Path] = issue.Code
+	}
+	if len(paths) != 2 || paths["/cta/title"] != CodeMaxLength || paths["/cta/description"] != CodeMaxLength {
+		t.Errorf("ValidateBrandReport() issues = %v, expected the title and description limits", report.Issues)
+	}
+}
@@ -2,10 +2,15 @@
 package warp
 
 import (
+	"net/http"
+
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/builder"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/contract"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/link"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/observability"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/registry"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/security"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
 	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/validator"
 )
@@ -15,18 +20,49 @@ type SDK struct {
 	Config     types.WarpConfig
 	Link       *link.WarpLink
 	Builder    *builder.WarpBuilder
+	Brands     *builder.BrandBuilder
+	Abis       *builder.AbiBuilder
+	Contracts  *contract.WarpContractLoader
 	Registry   *registry.WarpRegistry
 	Validator  *validator.WarpValidator
+	Analyzer   *security.WarpAnalyzer
+}
+
+// Option customizes the SDK created by NewSDK
+type Option func(config *types.WarpConfig)
+
+// WithObserver sets the observer that receives logs, spans and metrics from all SDK components
+func WithObserver(observer observability.Observer) Option {
+	return func(config *types.WarpConfig) {
+		config.Observer = observer
+	}
+}
+
+// WithHTTPTransport sets the transport used for all HTTP calls made by the SDK components,
+// e.g. a recorder.Recorder for deterministic tests
+func WithHTTPTransport(transport http.RoundTripper) Option {
+	return func(config *types.WarpConfig) {
+		config.HTTPTransport = transport
+	}
 }
 
 // NewSDK creates a new SDK instance with the specified configuration
-func NewSDK(config types.WarpConfig) *SDK {
+func NewSDK(config types.WarpConfig, opts ...Option) *SDK {
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.Observer = observability.OrNoop(config.Observer)
+
 	return &SDK{
 		Config:     config,
 		Link:       link.NewWarpLink(config),
 		Builder:    builder.NewWarpBuilder(config),
+		Brands:     builder.NewBrandBuilder(config),
+		Abis:       builder.NewAbiBuilder(config),
+		Contracts:  contract.NewWarpContractLoader(config),
 		Registry:   registry.NewWarpRegistry(config),
 		Validator:  validator.NewWarpValidator(config),
+		Analyzer:   security.NewWarpAnalyzer(config),
 	}
 }
 
@@ -0,0 +1,686 @@
+// Package warptest provides an in-process stand-in for the MultiversX chain API
+// and the warp index, for testing code that uses the SDK without network access
+package warptest
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/address"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/core"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+)
+
+// Fixture identifiers seeded by NewServer
+const (
+	// RegistryContract is the registry contract address served by the stand-in
+	RegistryContract = "erd1qqqqqqqqqqqqqpgqhe8t5jewej70zupmh44jurgn29psua5l2jps3ntjj3"
+
+	// Creator is the address that created all fixtures
+	Creator = "erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th"
+
+	// WarpAlias is the registered alias of the fixture warp
+	WarpAlias = "fixture-warp"
+
+	// FixtureTimestamp is the creation time of all fixtures
+	FixtureTimestamp int64 = 1700000000
+
+	// RegistryUnitPrice is the registry fee unit configured on the stand-in, 0.05 EGLD
+	RegistryUnitPrice = "50000000000000000"
+)
+
+// Transaction represents a transaction as returned by the chain API
+type Transaction struct {
+	TxHash    string `json:"txHash"`
+	Sender    string `json:"sender"`
+	Receiver  string `json:"receiver"`
+	Value     string `json:"value"`
+	Data      string `json:"data"` // base64 encoded
+	Timestamp int64  `json:"timestamp"`
+	Status    string `json:"status"`
+}
+
+// Account represents an account as returned by the chain API
+type Account struct {
+	Address      string `json:"address"`
+	Balance      string `json:"balance"`
+	Nonce        uint64 `json:"nonce"`
+	OwnerAddress string `json:"ownerAddress,omitempty"`
+	CodeHash     string `json:"codeHash,omitempty"`
+	IsVerified   bool   `json:"isVerified,omitempty"`
+}
+
+// Server is an httptest server emulating the chain API and the warp index
+type Server struct {
+	*httptest.Server
+
+	// WarpHash is the hash of the seeded fixture warp
+	WarpHash string
+
+	// BrandHash is the hash of the seeded fixture brand
+	BrandHash string
+
+	// AbiHash is the hash of the seeded fixture ABI
+	AbiHash string
+
+	// ContractCodeHash is the code hash of the registry contract, whose source is verified with the fixture ABI
+	ContractCodeHash string
+
+	// IndexSearchParamName is the query parameter the index reads search terms from.
+	// Set it before the first search to emulate an index with another name.
+	IndexSearchParamName string
+
+	mutex        sync.RWMutex
+	transactions map[string]Transaction
+	accounts     map[string]Account
+	registry     map[string]types.RegistryInfo
+	aliases      map[string]string
+	brands       map[string][]string
+	sources      map[string]types.AbiContents
+	hits         []types.WarpSearchHit
+}
+
+// NewServer starts a stand-in server seeded with a fixture warp, brand and registry entry.
+// The caller should call Close when finished.
+func NewServer() *Server {
+	s := NewEmptyServer()
+
+	s.BrandHash = s.AddBrand(FixtureBrand(), Creator)
+	s.AddRegisteredBrand(s.BrandHash, Creator)
+	s.WarpHash = s.AddWarp(FixtureWarp(), Creator)
+	s.AbiHash = s.AddAbi(FixtureAbi(), Creator)
+
+	alias := WarpAlias
+	brand := s.BrandHash
+	s.AddRegistryInfo(types.RegistryInfo{
+		Hash:      s.WarpHash,
+		Alias:     &alias,
+		Trust:     types.Verified,
+		Creator:   Creator,
+		CreatedAt: FixtureTimestamp,
+		Brand:     &brand,
+	})
+
+	s.AddAccount(Account{Address: Creator, Balance: "1000000000000000000"})
+	codeHash := sha256.Sum256([]byte(RegistryContract))
+	s.ContractCodeHash = hex.EncodeToString(codeHash[:])
+	s.AddAccount(Account{Address: RegistryContract, Balance: "0", OwnerAddress: Creator, CodeHash: s.ContractCodeHash, IsVerified: true})
+	s.AddVerifiedSource(RegistryContract, FixtureAbi().Content)
+
+	s.AddSearchHit(types.WarpSearchHit{
+		Hash:   s.WarpHash,
+		Alias:  WarpAlias,
+		Name:   FixtureWarp().Name,
+		Title:  FixtureWarp().Title,
+		Status: string(types.Verified),
+	})
+
+	return s
+}
+
+// NewEmptyServer starts a stand-in server without any fixtures.
+// The caller should call Close when finished.
+func NewEmptyServer() *Server {
+	s := &Server{
+		IndexSearchParamName: core.Config.DefaultIndexSearchParamName,
+		transactions:         make(map[string]Transaction),
+		accounts:             make(map[string]Account),
+		registry:             make(map[string]types.RegistryInfo),
+		aliases:              make(map[string]string),
+		brands:               make(map[string][]string),
+		sources:              make(map[string]types.AbiContents),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/transactions/", s.handleTransaction)
+	mux.HandleFunc("/accounts/", s.handleAccount)
+	mux.HandleFunc("/vm-values/query", s.handleQuery)
+	mux.HandleFunc("/search", s.handleSearch)
+	mux.HandleFunc("/abis/", s.handleAbi)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Config returns a WarpConfig pointed at the stand-in server
+func (s *Server) Config() types.WarpConfig {
+	return types.WarpConfig{
+		Env:                  types.Devnet,
+		ClientURL:            core.Config.DefaultClientURL(types.Devnet),
+		UserAddress:          Creator,
+		ChainAPIURL:          s.URL,
+		RegistryContract:     RegistryContract,
+		IndexURL:             s.URL,
+		IndexSearchParamName: s.IndexSearchParamName,
+	}
+}
+
+// FixtureWarp returns the warp seeded by NewServer
+func FixtureWarp() types.Warp {
+	description := "A warp served by the warptest stand-in"
+	fn := "stake"
+
+	return types.Warp{
+		Protocol:    "warp-0.0.2",
+		Name:        "fixture-warp",
+		Title:       "Fixture Warp",
+		Description: &description,
+		Actions: []types.WarpAction{
+			types.WarpContractAction{
+				Type:     types.ContractActionType,
+				Label:    "Stake",
+				Address:  RegistryContract,
+				Func:     &fn,
+				Args:     []string{},
+				GasLimit: 5000000,
+			},
+		},
+	}
+}
+
+// FixtureBrand returns the brand seeded by NewServer
+func FixtureBrand() types.Brand {
+	web := "https://example.com"
+
+	return types.Brand{
+		Protocol:    "brand-0.0.2",
+		Name:        "Fixture Brand",
+		Description: "A brand served by the warptest stand-in",
+		Logo:        "https://example.com/logo.png",
+		URLs:        &types.BrandURLs{Web: &web},
+	}
+}
+
+// fixtureAbiContent is the content of the ABI seeded by NewServer, in the MultiversX ABI format
+const fixtureAbiContent = `{
+	"name": "Staking",
+	"endpoints": [
+		{
+			"name": "stake",
+			"mutability": "mutable",
+			"payableInTokens": ["EGLD"],
+			"inputs": [],
+			"outputs": []
+		},
+		{
+			"name": "getStake",
+			"mutability": "readonly",
+			"inputs": [{"name": "address", "type": "Address"}],
+			"outputs": [{"type": "BigUint"}]
+		}
+	],
+	"types": {}
+}`
+
+// FixtureAbi returns the ABI seeded by NewServer, describing the fixture warp's contract
+func FixtureAbi() types.WarpAbi {
+	abi := types.WarpAbi{Protocol: "abi-0.0.2"}
+	if err := json.Unmarshal([]byte(fixtureAbiContent), &abi.Content); err != nil {
+		panic("warptest: failed to decode fixture ABI: " + err.Error())
+	}
+	return abi
+}
+
+// AbiURL returns the URL serving the content of an inscribed ABI, as a contract ABI file would be hosted
+func (s *Server) AbiURL(hash string) string {
+	return s.URL + "/abis/" + hash
+}
+
+// AddWarp inscribes a warp as a transaction sent by the creator and returns its hash
+func (s *Server) AddWarp(warp types.Warp, creator string) string {
+	data, err := json.Marshal(warp)
+	if err != nil {
+		panic("warptest: failed to encode warp: " + err.Error())
+	}
+	return s.AddInscription(data, creator)
+}
+
+// AddBrand inscribes a brand as a transaction sent by the creator and returns its hash
+func (s *Server) AddBrand(brand types.Brand, creator string) string {
+	data, err := json.Marshal(brand)
+	if err != nil {
+		panic("warptest: failed to encode brand: " + err.Error())
+	}
+	return s.AddInscription(data, creator)
+}
+
+// AddAbi inscribes an ABI as a transaction sent by the creator and returns its hash
+func (s *Server) AddAbi(abi types.WarpAbi, creator string) string {
+	data, err := json.Marshal(abi)
+	if err != nil {
+		panic("warptest: failed to encode ABI: " + err.Error())
+	}
+	return s.AddInscription(data, creator)
+}
+
+// AddInscription adds a transaction sent by the creator to itself carrying the data, and returns its hash
+func (s *Server) AddInscription(data []byte, creator string) string {
+	sum := sha256.Sum256(append([]byte(creator), data...))
+	hash := hex.EncodeToString(sum[:])
+
+	s.AddTransaction(Transaction{
+		TxHash:    hash,
+		Sender:    creator,
+		Receiver:  creator,
+		Value:     "0",
+		Data:      base64.StdEncoding.EncodeToString(data),
+		Timestamp: FixtureTimestamp,
+		Status:    "success",
+	})
+
+	return hash
+}
+
+// AddTransaction adds a transaction to the stand-in
+func (s *Server) AddTransaction(tx Transaction) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.transactions[tx.TxHash] = tx
+}
+
+// AddAccount adds an account to the stand-in
+func (s *Server) AddAccount(account Account) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.accounts[account.Address] = account
+}
+
+// AddVerifiedSource adds the ABI of the verified source code of a contract account
+func (s *Server) AddVerifiedSource(addr string, abi types.AbiContents) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sources[addr] = abi
+}
+
+// AddRegistryInfo adds a registry entry, indexed by hash and by alias if set
+func (s *Server) AddRegistryInfo(info types.RegistryInfo) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.registry[info.Hash] = info
+	if info.Alias != nil {
+		s.aliases[*info.Alias] = info.Hash
+	}
+}
+
+// AddRegisteredBrand adds a brand published in the registry by the creator
+func (s *Server) AddRegisteredBrand(hash string, creator string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.brands[creator] = append(s.brands[creator], hash)
+}
+
+// AddSearchHit adds an entry to the index
+func (s *Server) AddSearchHit(hit types.WarpSearchHit) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.hits = append(s.hits, hit)
+}
+
+// handleTransaction serves GET /transactions/{hash}
+func (s *Server) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	hash := strings.TrimPrefix(r.URL.Path, "/transactions/")
+
+	s.mutex.RLock()
+	tx, found := s.transactions[hash]
+	s.mutex.RUnlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, "Transaction not found")
+		return
+	}
+	writeJSON(w, tx)
+}
+
+// handleAbi serves GET /abis/{hash} with the content of the ABI inscribed in the transaction
+func (s *Server) handleAbi(w http.ResponseWriter, r *http.Request) {
+	hash := strings.TrimPrefix(r.URL.Path, "/abis/")
+
+	s.mutex.RLock()
+	tx, found := s.transactions[hash]
+	s.mutex.RUnlock()
+
+	var abi types.WarpAbi
+	data, err := base64.StdEncoding.DecodeString(tx.Data)
+	if !found || err != nil || json.Unmarshal(data, &abi) != nil {
+		writeError(w, http.StatusNotFound, "ABI not found")
+		return
+	}
+	writeJSON(w, abi.Content)
+}
+
+// handleAccount serves GET /accounts/{address}, GET /accounts/{address}/transactions
+// and GET /accounts/{address}/verification
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/accounts/"), "/")
+	addr := parts[0]
+
+	if len(parts) == 2 && parts[1] == "transactions" {
+		writeJSON(w, s.accountTransactions(addr, r.URL.Query()))
+		return
+	}
+	if len(parts) == 2 && parts[1] == "verification" {
+		s.handleVerification(w, addr)
+		return
+	}
+	if len(parts) != 1 {
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	s.mutex.RLock()
+	account, found := s.accounts[addr]
+	s.mutex.RUnlock()
+
+	if !found {
+		if !address.IsValid(addr) {
+			writeError(w, http.StatusBadRequest, "Invalid address")
+			return
+		}
+		account = Account{Address: addr, Balance: "0"}
+	}
+	writeJSON(w, account)
+}
+
+// handleVerification serves the code hash and ABI of a contract with a verified source
+func (s *Server) handleVerification(w http.ResponseWriter, addr string) {
+	s.mutex.RLock()
+	account := s.accounts[addr]
+	abi, found := s.sources[addr]
+	s.mutex.RUnlock()
+
+	if !found {
+		writeError(w, http.StatusNotFound, "Contract verification not found")
+		return
+	}
+	writeJSON(w, map[string]interface{}{
+		"codeHash": account.CodeHash,
+		"source":   map[string]interface{}{"abi": abi},
+	})
+}
+
+// accountTransactions returns a page of the transactions sent or received by an account, newest first.
+// It supports the sender, receiver, from and size query parameters of the chain API.
+func (s *Server) accountTransactions(addr string, query url.Values) []Transaction {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	sender := query.Get("sender")
+	receiver := query.Get("receiver")
+
+	txs := []Transaction{}
+	for _, tx := range s.transactions {
+		if tx.Sender != addr && tx.Receiver != addr {
+			continue
+		}
+		if sender != "" && tx.Sender != sender {
+			continue
+		}
+		if receiver != "" && tx.Receiver != receiver {
+			continue
+		}
+		txs = append(txs, tx)
+	}
+
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].Timestamp != txs[j].Timestamp {
+			return txs[i].Timestamp > txs[j].Timestamp
+		}
+		return txs[i].TxHash < txs[j].TxHash
+	})
+
+	from, _ := strconv.Atoi(query.Get("from"))
+	size, err := strconv.Atoi(query.Get("size"))
+	if err != nil || size <= 0 {
+		size = 25
+	}
+	if from >= len(txs) {
+		return []Transaction{}
+	}
+	if from+size > len(txs) {
+		size = len(txs) - from
+	}
+
+	return txs[from : from+size]
+}
+
+// vmQuery is the request body of POST /vm-values/query
+type vmQuery struct {
+	ScAddress string   `json:"scAddress"`
+	FuncName  string   `json:"funcName"`
+	Args      []string `json:"args"`
+}
+
+// handleQuery serves POST /vm-values/query for the registry contract views
+func (s *Server) handleQuery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var query vmQuery
+	if err := json.NewDecoder(r.Body).Decode(&query); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	if query.ScAddress != RegistryContract {
+		writeQueryResult(w, "contract not found", nil)
+		return
+	}
+
+	args := make([][]byte, len(query.Args))
+	for i, arg := range query.Args {
+		decoded, err := hex.DecodeString(arg)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid argument")
+			return
+		}
+		args[i] = decoded
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var info types.RegistryInfo
+	var found bool
+
+	switch query.FuncName {
+	case "getInfoByHash":
+		if len(args) == 1 {
+			info, found = s.registry[hex.EncodeToString(args[0])]
+		}
+	case "getInfoByAlias":
+		if len(args) == 1 {
+			if hash, ok := s.aliases[string(args[0])]; ok {
+				info, found = s.registry[hash]
+			}
+		}
+	case "getConfig":
+		writeQueryResult(w, "", s.encodeConfig())
+		return
+	case "getUserWarps":
+		if len(args) != 1 {
+			writeQueryResult(w, "wrong number of arguments", nil)
+			return
+		}
+		writeQueryResult(w, "", s.encodeUserWarps(args[0]))
+		return
+	case "getUserBrands":
+		if len(args) != 1 {
+			writeQueryResult(w, "wrong number of arguments", nil)
+			return
+		}
+		writeQueryResult(w, "", s.encodeUserBrands(args[0]))
+		return
+	default:
+		writeQueryResult(w, "invalid function (not found)", nil)
+		return
+	}
+
+	// Unknown entries are an empty optional result
+	if !found {
+		writeQueryResult(w, "", nil)
+		return
+	}
+
+	writeQueryResult(w, "", [][]byte{encodeRegistryInfo(info)})
+}
+
+// handleSearch serves GET /search on the index
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get(s.IndexSearchParamName))
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	hits := []types.WarpSearchHit{}
+	for _, hit := range s.hits {
+		haystack := strings.ToLower(strings.Join([]string{hit.Alias, hit.Name, hit.Title, hit.Description}, " "))
+		if strings.Contains(haystack, query) {
+			hits = append(hits, hit)
+		}
+	}
+
+	writeJSON(w, types.WarpSearchResult{Hits: hits})
+}
+
+// encodeConfig encodes the registry configuration returned by getConfig: unit price, then admins
+func (s *Server) encodeConfig() [][]byte {
+	unitPrice, _ := new(big.Int).SetString(RegistryUnitPrice, 10)
+	admin, _ := address.Decode(Creator)
+
+	return [][]byte{unitPrice.Bytes(), admin}
+}
+
+// encodeUserWarps encodes the registry entries created by an account as consecutive registry info multi-values
+func (s *Server) encodeUserWarps(pubKey []byte) [][]byte {
+	creator, _ := address.Encode(pubKey)
+
+	infos := []types.RegistryInfo{}
+	for _, info := range s.registry {
+		if info.Creator == creator {
+			infos = append(infos, info)
+		}
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].CreatedAt != infos[j].CreatedAt {
+			return infos[i].CreatedAt > infos[j].CreatedAt
+		}
+		return infos[i].Hash < infos[j].Hash
+	})
+
+	returnData := [][]byte{}
+	for _, info := range infos {
+		returnData = append(returnData, encodeRegistryInfo(info))
+	}
+	return returnData
+}
+
+// encodeUserBrands encodes the hashes of the brands published by an account
+func (s *Server) encodeUserBrands(pubKey []byte) [][]byte {
+	creator, _ := address.Encode(pubKey)
+
+	returnData := [][]byte{}
+	for _, hash := range s.brands[creator] {
+		decoded, _ := hex.DecodeString(hash)
+		returnData = append(returnData, decoded)
+	}
+	return returnData
+}
+
+// encodeRegistryInfo encodes a registry entry as the nested InfoView struct returned by the registry
+// contract: hash bytes, alias Option<bytes>, trust bytes, owner Address, created_at u64,
+// brand Option<bytes>, upgrade Option<bytes>
+func encodeRegistryInfo(info types.RegistryInfo) []byte {
+	hash, _ := hex.DecodeString(info.Hash)
+	creator, _ := address.Decode(info.Creator)
+	createdAt := make([]byte, 8)
+	binary.BigEndian.PutUint64(createdAt, uint64(info.CreatedAt))
+
+	var encoded []byte
+	encoded = append(encoded, nestedBytes(hash)...)
+	encoded = append(encoded, nestedOption(info.Alias, func(alias string) []byte { return []byte(alias) })...)
+	encoded = append(encoded, nestedBytes([]byte(info.Trust))...)
+	encoded = append(encoded, creator...)
+	encoded = append(encoded, createdAt...)
+	encoded = append(encoded, nestedOption(info.Brand, decodeHex)...)
+	encoded = append(encoded, nestedOption(info.Upgrade, decodeHex)...)
+	return encoded
+}
+
+// nestedBytes encodes a length prefixed nested bytes value
+func nestedBytes(data []byte) []byte {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(data)))
+	return append(length, data...)
+}
+
+// nestedOption encodes a nested Option<bytes> value
+func nestedOption(value *string, toBytes func(string) []byte) []byte {
+	if value == nil {
+		return []byte{0}
+	}
+	return append([]byte{1}, nestedBytes(toBytes(*value))...)
+}
+
+func decodeHex(value string) []byte {
+	decoded, _ := hex.DecodeString(value)
+	return decoded
+}
+
+// writeQueryResult writes a vm-values query response in the gateway format
+func writeQueryResult(w http.ResponseWriter, errorMessage string, returnData [][]byte) {
+	encoded := make([]string, len(returnData))
+	for i, data := range returnData {
+		encoded[i] = base64.StdEncoding.EncodeToString(data)
+	}
+
+	returnCode := "ok"
+	if errorMessage != "" {
+		returnCode = "user error"
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"data": map[string]interface{}{
+			"data": map[string]interface{}{
+				"returnData":    encoded,
+				"returnCode":    returnCode,
+				"returnMessage": errorMessage,
+			},
+		},
+		"code": "successful",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"statusCode": status,
+		"message":    message,
+		"timestamp":  time.Now().Unix(),
+	})
+}
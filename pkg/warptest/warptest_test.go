@@ -0,0 +1,97 @@
+package warptest_test
+
+import (
+	"testing"
+
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/builder"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/link"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/registry"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/types"
+	"github.com/ApurvaBardapurkar/sdk-warps-go/pkg/warptest"
+)
+
+func TestCreateFromTransactionHash(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	warp, err := builder.NewWarpBuilder(server.Config()).CreateFromTransactionHash(server.WarpHash, nil)
+	if err != nil {
+		t.Fatalf("CreateFromTransactionHash() error = %v", err)
+	}
+
+	if warp.Name != warptest.FixtureWarp().Name {
+		t.Errorf("warp.Name = %s, expected %s", warp.Name, warptest.FixtureWarp().Name)
+	}
+	if len(warp.Actions) != 1 || warp.Actions[0].GetType() != types.ContractActionType {
+		t.Errorf("warp.Actions = %v, expected a single contract action", warp.Actions)
+	}
+	if warp.Meta == nil || warp.Meta.Creator != warptest.Creator {
+		t.Errorf("warp.Meta = %v, expected creator %s", warp.Meta, warptest.Creator)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	warpLink := link.NewWarpLink(server.Config())
+	result, err := warpLink.Detect(warpLink.Build(types.HashIDType, server.WarpHash))
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	if !result.Match || result.Warp == nil {
+		t.Fatalf("Detect() = %v, expected a match", result)
+	}
+	if result.Warp.Title != warptest.FixtureWarp().Title {
+		t.Errorf("Detect().Warp.Title = %s, expected %s", result.Warp.Title, warptest.FixtureWarp().Title)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+
+	result, err := registry.NewWarpRegistry(server.Config()).Search("fixture")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if len(result.Hits) != 1 || result.Hits[0].Hash != server.WarpHash {
+		t.Errorf("Search() hits = %v, expected the fixture warp", result.Hits)
+	}
+}
+
+func TestSearchParamName(t *testing.T) {
+	server := warptest.NewServer()
+	defer server.Close()
+	server.IndexSearchParamName = "search"
+
+	config := server.Config()
+	if config.IndexSearchParamName != "search" {
+		t.Fatalf("Config().IndexSearchParamName = %s, expected search", config.IndexSearchParamName)
+	}
+	result, err := registry.NewWarpRegistry(config).Search("fixture")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(result.Hits) != 1 || result.Hits[0].Hash != server.WarpHash {
+		t.Errorf("Search() hits = %v, expected the fixture warp", result.Hits)
+	}
+
+	// The terms are read from the configured parameter
+	result, err = registry.NewWarpRegistry(config).Search("unknown")
+	if err != nil || len(result.Hits) != 0 {
+		t.Errorf("Search() = %v, %v, expected no hits", result, err)
+	}
+}
+
+func TestTransactionNotFound(t *testing.T) {
+	server := warptest.NewEmptyServer()
+	defer server.Close()
+
+	_, err := builder.NewWarpBuilder(server.Config()).CreateFromTransactionHash("unknown", nil)
+	if err == nil {
+		t.Error("CreateFromTransactionHash() error = nil, expected not found")
+	}
+}